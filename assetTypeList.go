@@ -1,8 +1,12 @@
 package main
 
 import (
+	"fmt"
+	"regexp"
+
 	"github.com/hyperledger-labs/cc-tools-demo/chaincode/assettypes"
 	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
 )
 
 var assetTypeList = []assets.AssetType{
@@ -12,4 +16,80 @@ var assetTypeList = []assets.AssetType{
 	assettypes.Inventory,
 	assettypes.RationAsset,
 	assettypes.Secret,
+	assettypes.RestockRequest,
+	assettypes.Stock,
+	assettypes.PurchaseOrder,
+	assettypes.Delivery,
+	assettypes.ApprovalPolicy,
+	assettypes.Proposal,
+	assettypes.OperatorAssignment,
+	assettypes.AdminArea,
+	assettypes.PriceSchedule,
+	assettypes.RationSale,
+	assettypes.ReimbursementClaim,
+	assettypes.PaymentReceipt,
+	assettypes.PickupBooking,
+	assettypes.PickupCode,
+	assettypes.IssuerKey,
+	assettypes.CardToken,
+	assettypes.OfflineDevice,
+	assettypes.OfflineBatch,
+	assettypes.OfflineException,
+	assettypes.BiometricBinding,
+	assettypes.Nominee,
+	assettypes.PriorityClass,
+}
+
+// permissionStartupCheck verifies that every asset type of the permission matrix is
+// registered, and that each of its props declares Writers allowing the callers the
+// matrix grants write access to. The chaincode refuses to start otherwise.
+func permissionStartupCheck() errors.ICCError {
+	for tag, permissions := range assettypes.PermissionMatrix {
+		assetType := assets.FetchAssetType(tag)
+		if assetType == nil {
+			return errors.NewCCError(fmt.Sprintf("permission matrix references unregistered asset type %s", tag), 500)
+		}
+
+		propTags := map[string]bool{"*": true}
+		for _, prop := range assetType.Props {
+			propTags[prop.Tag] = true
+
+			if len(prop.Writers) == 0 {
+				return errors.NewCCError(fmt.Sprintf("prop %s of protected asset type %s has no writers", prop.Tag, tag), 500)
+			}
+			// An empty list, unlike a missing one, leaves the prop to its own transactions
+			callers := permissions.PropCallers(prop)
+			if callers == nil {
+				return errors.NewCCError(fmt.Sprintf("permission matrix grants no caller write access to prop %s of asset type %s", prop.Tag, tag), 500)
+			}
+			for _, caller := range callers {
+				if !coveredByWriters(caller.MSP, prop.Writers) {
+					return errors.NewCCError(fmt.Sprintf("permission matrix grants %s write access to prop %s of asset type %s, which its writers deny", caller.MSP, prop.Tag, tag), 500)
+				}
+			}
+		}
+
+		for propTag := range permissions.Update {
+			if !propTags[propTag] {
+				return errors.NewCCError(fmt.Sprintf("permission matrix references unknown prop %s of asset type %s", propTag, tag), 500)
+			}
+		}
+	}
+
+	return nil
+}
+
+// coveredByWriters checks if a caller MSP, possibly a regexp prefixed with $, is allowed by prop writers
+func coveredByWriters(msp string, writers []string) bool {
+	for _, w := range writers {
+		if w == msp {
+			return true
+		}
+		if len(w) > 1 && w[0] == '$' && (len(msp) == 0 || msp[0] != '$') {
+			if match, err := regexp.MatchString(w[1:], msp); err == nil && match {
+				return true
+			}
+		}
+	}
+	return false
 }
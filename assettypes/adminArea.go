@@ -0,0 +1,54 @@
+package assettypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/assets"
+)
+
+// AdminArea is an entry of the administrative area reference table addresses are
+// validated against. Areas are identified by their path, the names of the area and
+// its ancestors from the division down joined with '/', e.g. "Dhaka/Gazipur/Kaliakair".
+var AdminArea = assets.AssetType{
+	Tag:         "adminArea",
+	Label:       "Administrative Area",
+	Description: "Division, district, upazila or union of Bangladesh",
+
+	Props: []assets.AssetProp{
+		{
+			// Primary Key
+			Required: true,
+			IsKey:    true,
+			Tag:      "path",
+			Label:    "Path",
+			DataType: "string",
+			Writers:  []string{"orgMSP"}, // This means only orgMSP can create the asset (others can edit)
+		},
+		{
+			Required: true,
+			Tag:      "name",
+			Label:    "Name",
+			DataType: "string",
+			Writers:  []string{"orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "level",
+			Label:    "Level",
+			DataType: "adminAreaLevel",
+			Writers:  []string{"orgMSP"},
+		},
+		{
+			// Enclosing area, empty for divisions
+			Tag:      "parent",
+			Label:    "Parent Area",
+			DataType: "->adminArea",
+			Writers:  []string{"orgMSP"},
+		},
+		{
+			// Geocode of the area, as published by the Bangladesh Bureau of Statistics
+			Tag:      "geoCode",
+			Label:    "Geocode",
+			DataType: "string",
+			Writers:  []string{"orgMSP"},
+		},
+	},
+}
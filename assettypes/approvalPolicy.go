@@ -0,0 +1,76 @@
+package assettypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+// ApprovalPolicy requires M of N organizations to approve a proposal before a transaction is executed
+var ApprovalPolicy = assets.AssetType{
+	Tag:         "approvalPolicy",
+	Label:       "Approval Policy",
+	Description: "Multi-signature policy of a high-impact transaction",
+
+	Props: []assets.AssetProp{
+		{
+			// Primary key: tag of the transaction the policy applies to
+			Required: true,
+			IsKey:    true,
+			Tag:      "txTag",
+			Label:    "Transaction Tag",
+			DataType: "string",
+			Writers:  []string{"orgMSP"}, // This means only orgMSP can create the asset (others can edit)
+		},
+		{
+			// MSP IDs of the organizations allowed to propose and approve
+			Required: true,
+			Tag:      "approvers",
+			Label:    "Approvers",
+			DataType: "[]string",
+			Writers:  []string{"orgMSP"},
+		},
+		{
+			// Number of approvers required to execute the transaction
+			Required: true,
+			Tag:      "threshold",
+			Label:    "Threshold",
+			DataType: "integer",
+			Writers:  []string{"orgMSP"},
+			Validate: func(threshold interface{}) error {
+				if toInt(threshold) < 1 {
+					return errors.NewCCError("Threshold must be at least 1", 400)
+				}
+				return nil
+			},
+		},
+		{
+			// Hours after which an unexecuted proposal expires
+			Tag:          "validityHours",
+			Label:        "Validity (hours)",
+			DataType:     "integer",
+			DefaultValue: 72,
+			Writers:      []string{"orgMSP"},
+			Validate: func(validityHours interface{}) error {
+				if toInt(validityHours) < 1 {
+					return errors.NewCCError("Validity must be at least 1 hour", 400)
+				}
+				return nil
+			},
+		},
+		{
+			// Calls measured smaller run without approval, for the transactions measuring their
+			// calls (replenishInventory, in base units), 0 requires approval for every call
+			Tag:          "minimumSize",
+			Label:        "Minimum Size",
+			DataType:     "integer",
+			DefaultValue: 0,
+			Writers:      []string{"orgMSP"},
+			Validate: func(minimumSize interface{}) error {
+				if toInt(minimumSize) < 0 {
+					return errors.NewCCError("Minimum size cannot be negative", 400)
+				}
+				return nil
+			},
+		},
+	},
+}
@@ -0,0 +1,63 @@
+package assettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// BiometricBinding holds the salted hash of the fingerprint template ID of a member,
+// available only to org1. The raw biometric never reaches the ledger.
+// Collections.json configuration is necessary
+var BiometricBinding = assets.AssetType{
+	Tag:         "biometricBinding",
+	Label:       "Biometric Binding",
+	Description: "Salted hash of the fingerprint template ID of a member",
+
+	Readers: []string{"org1MSP", "orgMSP"},
+	Props: []assets.AssetProp{
+		{
+			// Primary Key
+			Required: true,
+			IsKey:    true,
+			Tag:      "member",
+			Label:    "Member",
+			DataType: "->member",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Salt of the hash, the ID of the binding transaction
+			Required: true,
+			Tag:      "salt",
+			Label:    "Salt",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// SHA-256 of the template hash salted, the template hash itself is never stored
+			Required: true,
+			Tag:      "templateHash",
+			Label:    "Template Hash",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Tag:          "failedAttempts",
+			Label:        "Failed Attempts",
+			DataType:     "integer",
+			DefaultValue: 0,
+			Writers:      []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Set after MaxBiometricAttempts failed verifications, until the member binds again
+			Tag:          "locked",
+			Label:        "Locked",
+			DataType:     "boolean",
+			DefaultValue: false,
+			Writers:      []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "boundDate",
+			Label:    "Bound Date",
+			DataType: "datetime",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+	},
+}
@@ -0,0 +1,67 @@
+package assettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// CardToken records a ration card token issued by an org. Tokens are signed off-chain, the
+// ledger only keeps the hash of each token along with the claims it was verified against, and
+// the salt of the NID hash it carries, which verifiers fetch to match the holder's NID.
+var CardToken = assets.AssetType{
+	Tag:         "cardToken",
+	Label:       "Card Token",
+	Description: "Ration card token issued for offline verification",
+
+	Props: []assets.AssetProp{
+		{
+			// Primary key: cardtoken.Hash of the token
+			Required: true,
+			IsKey:    true,
+			Tag:      "tokenHash",
+			Label:    "Token Hash",
+			DataType: "string",
+			Writers:  []string{`org3MSP`, "orgMSP"}, // This means only org3 can create the asset
+		},
+		{
+			Required: true,
+			Tag:      "member",
+			Label:    "Member",
+			DataType: "->member",
+			Writers:  []string{`org3MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "rationCardNumber",
+			Label:    "Ration Card Number",
+			DataType: "string",
+			Writers:  []string{`org3MSP`, "orgMSP"},
+		},
+		{
+			// Base64 salt the NID hash of the token is keyed with, see cardtoken.HashNID
+			Required: true,
+			Tag:      "nidSalt",
+			Label:    "NID Salt",
+			DataType: "string",
+			Writers:  []string{`org3MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "issuerKey",
+			Label:    "Issuer Key",
+			DataType: "->issuerKey",
+			Writers:  []string{`org3MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "issuedDate",
+			Label:    "Issued Date",
+			DataType: "datetime",
+			Writers:  []string{`org3MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "expiryDate",
+			Label:    "Expiry Date",
+			DataType: "datetime",
+			Writers:  []string{`org3MSP`, "orgMSP"},
+		},
+	},
+}
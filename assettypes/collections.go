@@ -0,0 +1,17 @@
+package assettypes
+
+// CollectionConfig holds the settings of a private data collection which differ from the
+// defaults of the collection generator
+type CollectionConfig struct {
+	BlockToLive    int  // Blocks after which the private data is purged, 0 keeps it forever
+	MemberOnlyRead bool // Only clients of the collection orgs can read it
+}
+
+// Collections holds the collection settings of the private asset types not using the defaults
+var Collections = map[string]CollectionConfig{
+	// Bindings are kept for as long as the member, not purged with the other private data
+	"biometricBinding": {BlockToLive: 0, MemberOnlyRead: true},
+	// Codes are checked by the operators of org1, whose calls are endorsed by the peers of
+	// the orgs holding the collection
+	"pickupCode": {BlockToLive: 1000000, MemberOnlyRead: false},
+}
@@ -0,0 +1,43 @@
+package assettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// Delivery is a partial or final delivery of ration batches against a purchase order
+var Delivery = assets.AssetType{
+	Tag:         "delivery",
+	Label:       "Delivery",
+	Description: "Delivery of ration batches against a purchase order",
+
+	Props: []assets.AssetProp{
+		{
+			// Primary key: transaction which recorded the delivery
+			Required: true,
+			IsKey:    true,
+			Tag:      "deliveryId",
+			Label:    "Delivery ID",
+			DataType: "string",
+			Writers:  []string{`org2MSP`, "orgMSP"}, // This means only org2 can create the asset (others can edit)
+		},
+		{
+			Required: true,
+			Tag:      "purchaseOrder",
+			Label:    "Purchase Order",
+			DataType: "->purchaseOrder",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			// Ration batches delivered
+			Required: true,
+			Tag:      "rations",
+			Label:    "Rations",
+			DataType: "[]->ration",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "deliveryDate",
+			Label:    "Delivery Date",
+			DataType: "datetime",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+	},
+}
@@ -27,6 +27,7 @@ var DistributionPoint = assets.AssetType{
 			Tag:      "name",
 			Label:    "Name of the distribution point",
 			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"},
 			// Validate function
 			Validate: func(name interface{}) error {
 				nameStr := name.(string)
@@ -43,6 +44,13 @@ var DistributionPoint = assets.AssetType{
 			DataType: "address",
 			Writers:  []string{`org1MSP`, "orgMSP"},
 		},
+		{
+			// Administrative area the reports of the region roll up to
+			Tag:      "region",
+			Label:    "Region",
+			DataType: "->adminArea",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
 		{
 			// Optional property
 			Tag:      "coordinates",
@@ -105,6 +113,7 @@ var DistributionPoint = assets.AssetType{
 			Tag:      "inspectionStatus",
 			Label:    "Inspection Status",
 			DataType: "inspectionStatus",
+			Writers:  []string{`org1MSP`, "orgMSP"},
 		},
 		{
 			// Optional property
@@ -113,5 +122,12 @@ var DistributionPoint = assets.AssetType{
 			DataType: "string",
 			Writers:  []string{`org1MSP`, "orgMSP"},
 		},
+		{
+			// Optional property, set by setPickupSchedule
+			Tag:      "pickupSchedule",
+			Label:    "Pickup Schedule",
+			DataType: "rationPickupSchedule",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
 	},
 }
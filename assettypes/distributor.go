@@ -14,6 +14,7 @@ type Distributor struct {
 	DistributorID      string                `json:"distributorId"`
 	Name               string                `json:"name"`
 	Address            datatypes.Address     `json:"address"`
+	Region             string                `json:"region"` // Key of the adminArea asset
 	ContactInformation datatypes.ContactInfo `json:"contactInformation"`
 	LicenseNumber      string                `json:"licenseNumber"`
 	LicenseIssueDate   string                `json:"licenseIssueDate"`
@@ -43,6 +44,7 @@ var DistributorAsset = assets.AssetType{
 			Tag:      "name",
 			Label:    "Name of the distributor",
 			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"},
 			// Validate function
 			Validate: func(name interface{}) error {
 				nameStr := name.(string)
@@ -57,18 +59,28 @@ var DistributorAsset = assets.AssetType{
 			Tag:      "address",
 			Label:    "Address",
 			DataType: "address",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Administrative area the reports of the region roll up to
+			Tag:      "region",
+			Label:    "Region",
+			DataType: "->adminArea",
+			Writers:  []string{`org1MSP`, "orgMSP"},
 		},
 		{
 			// Optional property
 			Tag:      "contactInformation",
 			Label:    "Contact Information",
 			DataType: "contactInfo",
+			Writers:  []string{`org1MSP`, "orgMSP"},
 		},
 		{
 			// Optional property
 			Tag:      "licenseNumber",
 			Label:    "License Number",
 			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"},
 			Validate: func(licenseNumber interface{}) error {
 				licenseNumberStr, ok := licenseNumber.(string)
 				if !ok {
@@ -167,12 +179,14 @@ var DistributorAsset = assets.AssetType{
 			Tag:      "licenseIssueDate",
 			Label:    "License Issue Date",
 			DataType: "datetime",
+			Writers:  []string{`org1MSP`, "orgMSP"},
 		},
 		{
 			// Optional property
 			Tag:      "licenseExpiryDate",
 			Label:    "License Expiry Date",
 			DataType: "datetime",
+			Writers:  []string{`org1MSP`, "orgMSP"},
 		},
 		{
 			// Optional property
@@ -186,6 +200,7 @@ var DistributorAsset = assets.AssetType{
 			Tag:      "lastInspectionDate",
 			Label:    "Last Inspection Date",
 			DataType: "datetime",
+			Writers:  []string{`org1MSP`, "orgMSP"},
 		},
 	},
 }
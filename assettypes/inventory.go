@@ -23,12 +23,14 @@ var Inventory = assets.AssetType{
 			Tag:      "rations",
 			Label:    "Ration Collection",
 			DataType: "[]->ration",
+			Writers:  []string{`org3MSP`, "orgMSP"},
 		},
 		{
 			// Asset reference list
 			Tag:      "entranceCode",
 			Label:    "Entrance Code for the Inventory",
 			DataType: "->secret",
+			Writers:  []string{`org3MSP`, "orgMSP"},
 		},
 	},
 }
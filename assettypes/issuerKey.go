@@ -0,0 +1,66 @@
+package assettypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/cardtoken"
+	"github.com/hyperledger-labs/cc-tools/assets"
+)
+
+// IssuerKey is a public key an org signs ration card tokens with. Keys are published so
+// distribution points can verify tokens offline, and stay published once retired so the
+// tokens issued before their retirement remain valid until they expire.
+var IssuerKey = assets.AssetType{
+	Tag:         "issuerKey",
+	Label:       "Issuer Key",
+	Description: "Public key ration card tokens are signed with",
+
+	Props: []assets.AssetProp{
+		{
+			// Primary key
+			Required: true,
+			IsKey:    true,
+			Tag:      "keyId",
+			Label:    "Key ID",
+			DataType: "string",
+			Writers:  []string{`org3MSP`, "orgMSP"}, // This means only org3 can create the asset
+		},
+		{
+			// MSP ID of the org signing with the key
+			Required: true,
+			Tag:      "issuer",
+			Label:    "Issuer",
+			DataType: "string",
+			Writers:  []string{`org3MSP`, "orgMSP"},
+		},
+		{
+			// Base64 Ed25519 public key
+			Required: true,
+			Tag:      "publicKey",
+			Label:    "Public Key",
+			DataType: "string",
+			Writers:  []string{`org3MSP`, "orgMSP"},
+			Validate: func(publicKey interface{}) error {
+				_, err := cardtoken.DecodePublicKey(publicKey.(string))
+				return err
+			},
+		},
+		{
+			Tag:          "status",
+			Label:        "Status",
+			DataType:     "issuerKeyStatus",
+			DefaultValue: "active",
+			Writers:      []string{`org3MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "activatedDate",
+			Label:    "Activated Date",
+			DataType: "datetime",
+			Writers:  []string{`org3MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "retiredDate",
+			Label:    "Retired Date",
+			DataType: "datetime",
+			Writers:  []string{`org3MSP`, "orgMSP"},
+		},
+	},
+}
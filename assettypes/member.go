@@ -87,6 +87,13 @@ var Member = assets.AssetType{
 			DataType: "boolean",
 			Writers:  []string{`org1MSP`, "orgMSP"}, // This means only org1 can create the asset (others can edit)
 		},
+		{
+			// Pregnancy, for the pregnancy priority class
+			Tag:      "pregnant",
+			Label:    "Pregnant",
+			DataType: "boolean",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
 		{
 			// New property: RationCardNumber
 			Tag:      "rationCardNumber",
@@ -123,11 +130,47 @@ var Member = assets.AssetType{
 			Writers:  []string{`org1MSP`, "orgMSP"}, // This means only org1 can create the asset (others can edit)
 		},
 		{
-			// New property: RationDistributionHistory
+			// Last distribution to the member, written by buyRation before each distribution was
+			// recorded as a rationSale
 			Tag:      "rationDistributionHistory",
 			Label:    "Ration Distribution History",
 			DataType: "rationDistributionHistory",
 			Writers:  []string{`org1MSP`, "orgMSP"}, // This means only org1 can create the asset (others can edit)
 		},
+		{
+			// Set when a fingerprint is bound, its hash is kept in the biometricBinding private collection
+			Tag:          "biometricBound",
+			Label:        "Biometric Bound",
+			DataType:     "boolean",
+			DefaultValue: false,
+			Writers:      []string{`org1MSP`, "orgMSP"},
+		},
+		// Archival: archived assets are kept on the ledger but left out of default searches
+		{
+			Tag:          "archived",
+			Label:        "Archived",
+			DataType:     "boolean",
+			DefaultValue: false,
+			Writers:      []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "archiveReason",
+			Label:    "Archive Reason",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// MSP ID of the admin who archived the asset
+			Tag:      "archivedBy",
+			Label:    "Archived By",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "archivedDate",
+			Label:    "Archive Date",
+			DataType: "datetime",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
 	},
 }
@@ -0,0 +1,79 @@
+package assettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// Nominee is a person a member authorizes to collect their rations, for a validity period.
+// A nominee may collect for a limited number of cards at once.
+var Nominee = assets.AssetType{
+	Tag:         "nominee",
+	Label:       "Nominee",
+	Description: "Person authorized to collect the rations of a member",
+
+	Props: []assets.AssetProp{
+		{
+			// Primary key: member the nominee collects for
+			Required: true,
+			IsKey:    true,
+			Tag:      "principal",
+			Label:    "Principal",
+			DataType: "->member",
+			Writers:  []string{`org1MSP`, "orgMSP"}, // This means only org1 can create the asset (others can edit)
+		},
+		{
+			// Primary key: NID of the nominee
+			Required: true,
+			IsKey:    true,
+			Tag:      "nid",
+			Label:    "Nominee NID",
+			DataType: "nid",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "name",
+			Label:    "Nominee Name",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Relationship of the nominee to the member, e.g. son or neighbour
+			Tag:      "relationship",
+			Label:    "Relationship",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "validFrom",
+			Label:    "Valid From",
+			DataType: "datetime",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "validUntil",
+			Label:    "Valid Until",
+			DataType: "datetime",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Tag:          "removed",
+			Label:        "Removed",
+			DataType:     "boolean",
+			DefaultValue: false,
+			Writers:      []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "removalReason",
+			Label:    "Removal Reason",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "removedDate",
+			Label:    "Removed Date",
+			DataType: "datetime",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+	},
+}
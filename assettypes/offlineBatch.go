@@ -0,0 +1,75 @@
+package assettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// OfflineBatch records the replay of a batch of distributions recorded offline
+var OfflineBatch = assets.AssetType{
+	Tag:         "offlineBatch",
+	Label:       "Offline Batch",
+	Description: "Batch of distributions recorded offline and replayed on the ledger",
+
+	Props: []assets.AssetProp{
+		{
+			// Primary key: submitOfflineBatch transaction
+			Required: true,
+			IsKey:    true,
+			Tag:      "batchId",
+			Label:    "Batch ID",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"}, // This means only org1 can create the asset
+		},
+		{
+			Required: true,
+			Tag:      "device",
+			Label:    "Device",
+			DataType: "->offlineDevice",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "distributionPoint",
+			Label:    "Distribution Point",
+			DataType: "->distributionPoint",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "firstSequence",
+			Label:    "First Sequence",
+			DataType: "integer",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "lastSequence",
+			Label:    "Last Sequence",
+			DataType: "integer",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "recorded",
+			Label:    "Distributions Recorded",
+			DataType: "integer",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "exceptions",
+			Label:    "Exceptions",
+			DataType: "integer",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Sequences skipped since the previous batch of the device, which may hide deleted records
+			Tag:      "missingSequences",
+			Label:    "Missing Sequences",
+			DataType: "integer",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "submittedDate",
+			Label:    "Submitted Date",
+			DataType: "datetime",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+	},
+}
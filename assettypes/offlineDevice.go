@@ -0,0 +1,59 @@
+package assettypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/cardtoken"
+	"github.com/hyperledger-labs/cc-tools/assets"
+)
+
+// OfflineDevice is a device recording distributions while its distribution point is offline.
+// It signs the batches it submits, and its last replayed sequence keeps a batch from being
+// replayed twice.
+var OfflineDevice = assets.AssetType{
+	Tag:         "offlineDevice",
+	Label:       "Offline Device",
+	Description: "Device recording distributions of a distribution point while offline",
+
+	Props: []assets.AssetProp{
+		{
+			// Primary key
+			Required: true,
+			IsKey:    true,
+			Tag:      "deviceId",
+			Label:    "Device ID",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"}, // This means only org1 can create the asset
+		},
+		{
+			Required: true,
+			Tag:      "distributionPoint",
+			Label:    "Distribution Point",
+			DataType: "->distributionPoint",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Base64 Ed25519 public key the device signs its batches with
+			Required: true,
+			Tag:      "publicKey",
+			Label:    "Public Key",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+			Validate: func(publicKey interface{}) error {
+				_, err := cardtoken.DecodePublicKey(publicKey.(string))
+				return err
+			},
+		},
+		{
+			Tag:          "lastSequence",
+			Label:        "Last Sequence",
+			DataType:     "integer",
+			DefaultValue: 0,
+			Writers:      []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "registeredDate",
+			Label:    "Registered Date",
+			DataType: "datetime",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+	},
+}
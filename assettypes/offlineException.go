@@ -0,0 +1,82 @@
+package assettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// OfflineException is a distribution of an offline batch which could not be replayed, left
+// for a supervisor to review
+var OfflineException = assets.AssetType{
+	Tag:         "offlineException",
+	Label:       "Offline Exception",
+	Description: "Distribution recorded offline which conflicts with the ledger",
+
+	Props: []assets.AssetProp{
+		{
+			// Primary key: device ID and sequence of the distribution
+			Required: true,
+			IsKey:    true,
+			Tag:      "exceptionId",
+			Label:    "Exception ID",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"}, // This means only org1 can create the asset
+		},
+		{
+			Required: true,
+			Tag:      "batch",
+			Label:    "Offline Batch",
+			DataType: "->offlineBatch",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "distributionPoint",
+			Label:    "Distribution Point",
+			DataType: "->distributionPoint",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "distribution",
+			Label:    "Distribution",
+			DataType: "offlineDistribution",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "reason",
+			Label:    "Reason",
+			DataType: "offlineExceptionReason",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "detail",
+			Label:    "Detail",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Tag:          "resolved",
+			Label:        "Resolved",
+			DataType:     "boolean",
+			DefaultValue: false,
+			Writers:      []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "resolution",
+			Label:    "Resolution",
+			DataType: "string",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "resolvedBy",
+			Label:    "Resolved By",
+			DataType: "string",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "resolvedDate",
+			Label:    "Resolved Date",
+			DataType: "datetime",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+	},
+}
@@ -0,0 +1,57 @@
+package assettypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/assets"
+)
+
+// OperatorAssignment binds an operator identity to a distribution point it may act on.
+// The operator is identified by the MSP of the caller along with the "operatorId" attribute
+// of its X.509 certificate, as each organization's CA issues its own operator IDs.
+var OperatorAssignment = assets.AssetType{
+	Tag:         "operatorAssignment",
+	Label:       "Operator Assignment",
+	Description: "Assignment of an operator to a distribution point",
+
+	Props: []assets.AssetProp{
+		{
+			// Composite Key
+			Required: true,
+			IsKey:    true,
+			Tag:      "operatorMsp",
+			Label:    "Operator MSP",
+			DataType: "string",
+			Writers:  []string{"orgMSP"},
+		},
+		{
+			// Composite Key
+			Required: true,
+			IsKey:    true,
+			Tag:      "operatorId",
+			Label:    "Operator ID",
+			DataType: "string",
+			Writers:  []string{"orgMSP"}, // This means only orgMSP can create the asset (others can edit)
+		},
+		{
+			// Composite Key
+			Required: true,
+			IsKey:    true,
+			Tag:      "distributionPoint",
+			Label:    "Distribution Point",
+			DataType: "->distributionPoint",
+			Writers:  []string{"orgMSP"},
+		},
+		{
+			// MSP ID of the admin who made the assignment
+			Tag:      "assignedBy",
+			Label:    "Assigned By",
+			DataType: "string",
+			Writers:  []string{"orgMSP"},
+		},
+		{
+			Tag:      "assignedDate",
+			Label:    "Assignment Date",
+			DataType: "datetime",
+			Writers:  []string{"orgMSP"},
+		},
+	},
+}
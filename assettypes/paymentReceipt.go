@@ -0,0 +1,60 @@
+package assettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// PaymentReceipt records a mobile wallet payment settling a ration sale. It is keyed by the
+// provider and its transaction reference, so a receipt settles a single sale.
+var PaymentReceipt = assets.AssetType{
+	Tag:         "paymentReceipt",
+	Label:       "Payment Receipt",
+	Description: "Mobile wallet payment of the co-payment of a ration sale",
+
+	Props: []assets.AssetProp{
+		{
+			// Composite Key
+			Required: true,
+			IsKey:    true,
+			Tag:      "provider",
+			Label:    "Provider",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"}, // This means only org1 can create the asset (others can edit)
+		},
+		{
+			// Composite Key: transaction reference given by the provider
+			Required: true,
+			IsKey:    true,
+			Tag:      "reference",
+			Label:    "Reference",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "amount",
+			Label:    "Amount",
+			DataType: "money",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "sale",
+			Label:    "Ration Sale",
+			DataType: "->rationSale",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "member",
+			Label:    "Member",
+			DataType: "->member",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "recordedDate",
+			Label:    "Recorded Date",
+			DataType: "datetime",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+	},
+}
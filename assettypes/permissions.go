@@ -0,0 +1,121 @@
+package assettypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+)
+
+// AssetPermissions declares which callers may create, update and delete an asset type
+// through the generic asset transactions. Transactions with their own routine are
+// governed by their Callers, and every write is still bound to the prop Writers.
+type AssetPermissions struct {
+	Create []accesscontrol.Caller            `json:"create"`
+	Update map[string][]accesscontrol.Caller `json:"update"` // By prop tag, "*" applies to the props not listed
+	Delete []accesscontrol.Caller            `json:"delete"`
+}
+
+// PropCallers returns the callers allowed to write a prop: the creators for key props
+// (which cannot be updated), the updaters of the prop otherwise
+func (p AssetPermissions) PropCallers(prop assets.AssetProp) []accesscontrol.Caller {
+	if prop.IsKey {
+		return p.Create
+	}
+	if callers, ok := p.Update[prop.Tag]; ok {
+		return callers
+	}
+	return p.Update["*"]
+}
+
+var (
+	org1Admins  = []accesscontrol.Caller{{MSP: "org1MSP", OU: "admin"}, {MSP: "orgMSP", OU: "admin"}}
+	org2Admins  = []accesscontrol.Caller{{MSP: "org2MSP", OU: "admin"}, {MSP: "orgMSP", OU: "admin"}}
+	org3Admins  = []accesscontrol.Caller{{MSP: "org3MSP", OU: "admin"}, {MSP: "orgMSP", OU: "admin"}}
+	superAdmins = []accesscontrol.Caller{{MSP: "orgMSP", OU: "admin"}}
+	noCallers   = []accesscontrol.Caller{} // Written by their own transactions only
+)
+
+// PermissionMatrix holds the permissions of the protected asset types. Every prop of a
+// protected asset type must declare Writers covering the callers allowed to write it,
+// which is verified when the chaincode starts.
+//
+// Assets driven by a workflow (ration cards, stock, orders...) are only written by their
+// own transactions, so the generic transactions are left to orgMSP. Proposals and approval
+// policies, which guard the other transactions, cannot be written by them at all.
+var PermissionMatrix = map[string]AssetPermissions{
+	"member": {
+		Create: org1Admins,
+		Update: map[string][]accesscontrol.Caller{
+			"*":                         org1Admins,
+			"rationCardNumber":          superAdmins, // Set by issueRationCard
+			"rationCardStatus":          superAdmins,
+			"rationCardIssuedDate":      superAdmins,
+			"rationCardExpiryDate":      superAdmins,
+			"rationCardCategory":        superAdmins,
+			"rationDistributionHistory": superAdmins, // Written by buyRation before rationSale recorded distributions
+			"biometricBound":            superAdmins, // Set by bindBiometric
+			"archived":                  superAdmins, // Set by archiveMember and restoreMember
+			"archiveReason":             superAdmins,
+			"archivedBy":                superAdmins,
+			"archivedDate":              superAdmins,
+		},
+		Delete: superAdmins,
+	},
+	"distributor": {
+		Create: org1Admins,
+		Update: map[string][]accesscontrol.Caller{"*": org1Admins},
+		Delete: superAdmins,
+	},
+	"distributionPoint": {
+		Create: org1Admins,
+		Update: map[string][]accesscontrol.Caller{"*": org1Admins},
+		Delete: superAdmins,
+	},
+	"inventory": {
+		Create: org3Admins,
+		Update: map[string][]accesscontrol.Caller{"*": org3Admins},
+		Delete: org3Admins,
+	},
+	"secret": {
+		Create: org2Admins,
+		Update: map[string][]accesscontrol.Caller{
+			"*": {{MSP: "org2MSP", OU: "admin"}, {MSP: "org3MSP", OU: "admin"}, {MSP: "orgMSP", OU: "admin"}},
+		},
+		Delete: org2Admins,
+	},
+	"ration": {
+		Create: superAdmins, // Created by createRation against a purchase order
+		Update: map[string][]accesscontrol.Caller{
+			"*":             org2Admins,
+			"purchaseOrder": superAdmins,
+			"delivery":      superAdmins, // Set by recordDelivery
+			"stockedAt":     superAdmins, // Set by replenishInventory
+			"archived":      superAdmins, // Set by archiveRation and restoreRation
+			"archiveReason": superAdmins,
+			"archivedBy":    superAdmins,
+			"archivedDate":  superAdmins,
+		},
+		Delete: org2Admins,
+	},
+	"restockRequest":     {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"stock":              {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"purchaseOrder":      {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"delivery":           {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"approvalPolicy":     {Create: noCallers, Update: map[string][]accesscontrol.Caller{"*": noCallers}, Delete: noCallers},
+	"proposal":           {Create: noCallers, Update: map[string][]accesscontrol.Caller{"*": noCallers}, Delete: noCallers},
+	"operatorAssignment": {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"adminArea":          {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"priceSchedule":      {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"rationSale":         {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"reimbursementClaim": {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"paymentReceipt":     {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"pickupBooking":      {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"pickupCode":         {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"issuerKey":          {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"cardToken":          {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"offlineDevice":      {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"offlineBatch":       {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"offlineException":   {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"biometricBinding":   {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"nominee":            {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+	"priorityClass":      {Create: superAdmins, Update: map[string][]accesscontrol.Caller{"*": superAdmins}, Delete: superAdmins},
+}
@@ -0,0 +1,90 @@
+package assettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// PickupBooking is a pickup booked by a member at a distribution point. The member proves
+// their presence with a one-time code issued by org3, whose hash is kept in the pickupCode
+// private collection. A booking covers every ration category collected on the visit.
+var PickupBooking = assets.AssetType{
+	Tag:         "pickupBooking",
+	Label:       "Pickup Booking",
+	Description: "Pickup of a ration booked by a member at a distribution point",
+
+	Props: []assets.AssetProp{
+		{
+			// Primary key: bookPickup transaction
+			Required: true,
+			IsKey:    true,
+			Tag:      "bookingId",
+			Label:    "Booking ID",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"}, // This means only org1 can create the asset (others can edit)
+		},
+		{
+			Required: true,
+			Tag:      "member",
+			Label:    "Member",
+			DataType: "->member",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "distributionPoint",
+			Label:    "Distribution Point",
+			DataType: "->distributionPoint",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "pickupDate",
+			Label:    "Pickup Date",
+			DataType: "datetime",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// The pickup code cannot be used after this date
+			Required: true,
+			Tag:      "expiryDate",
+			Label:    "Expiry Date",
+			DataType: "datetime",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Booked by a priority member, in a reserved slot if needed
+			Tag:          "priority",
+			Label:        "Priority",
+			DataType:     "boolean",
+			DefaultValue: false,
+			Writers:      []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// The ration is delivered at the member's home, for priority classes allowing it
+			Tag:          "homeDelivery",
+			Label:        "Home Delivery",
+			DataType:     "boolean",
+			DefaultValue: false,
+			Writers:      []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Tag:          "status",
+			Label:        "Status",
+			DataType:     "pickupBookingStatus",
+			DefaultValue: "booked",
+			Writers:      []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Sales of the rations collected on the visit
+			Tag:      "sales",
+			Label:    "Ration Sales",
+			DataType: "[]->rationSale",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Sale of the ration collected with the bookings made before they covered a visit
+			Tag:      "sale",
+			Label:    "Ration Sale",
+			DataType: "->rationSale",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+	},
+}
@@ -0,0 +1,41 @@
+package assettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// PickupCode holds the hash of the one-time code of a pickup booking, issued by org3 and held
+// only by its peers, so the operators of org1 never see it. The collection is not
+// member-only-read, for the org1 calls of verifyPickupCode and buyRation endorsed by org3.
+// Collections.json configuration is necessary
+var PickupCode = assets.AssetType{
+	Tag:         "pickupCode",
+	Label:       "Pickup Code",
+	Description: "Hash of the one-time code proving the presence of a member at a pickup",
+
+	Readers: []string{"org3MSP", "orgMSP"},
+	Props: []assets.AssetProp{
+		{
+			// Primary Key
+			Required: true,
+			IsKey:    true,
+			Tag:      "booking",
+			Label:    "Pickup Booking",
+			DataType: "->pickupBooking",
+			Writers:  []string{`org3MSP`, "orgMSP"},
+		},
+		{
+			// SHA-256 of the code salted with the booking ID, the code itself is never stored
+			Required: true,
+			Tag:      "codeHash",
+			Label:    "Code Hash",
+			DataType: "string",
+			Writers:  []string{`org3MSP`, "orgMSP"},
+		},
+		{
+			Tag:          "failedAttempts",
+			Label:        "Failed Attempts",
+			DataType:     "integer",
+			DefaultValue: 0,
+			Writers:      []string{`org1MSP`, `org3MSP`, "orgMSP"}, // Counted by verifyPickupCode, called by the operators
+		},
+	},
+}
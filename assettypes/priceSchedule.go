@@ -0,0 +1,89 @@
+package assettypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+// PriceSchedule is the price of a ration category for a ration card category from a start
+// date. Members pay the subsidized price, and the distributor claims back the share of the
+// difference with the market price borne by the government.
+var PriceSchedule = assets.AssetType{
+	Tag:         "priceSchedule",
+	Label:       "Price Schedule",
+	Description: "Market and subsidized price of a ration category for a ration card category over a period",
+
+	Props: []assets.AssetProp{
+		{
+			// Composite Key
+			Required: true,
+			IsKey:    true,
+			Tag:      "category",
+			Label:    "Ration Category",
+			DataType: "rationCategory",
+			Writers:  []string{`org2MSP`, "orgMSP"}, // This means only org2 can create the asset (others can edit)
+		},
+		{
+			// Composite Key
+			Required: true,
+			IsKey:    true,
+			Tag:      "cardCategory",
+			Label:    "Ration Card Category",
+			DataType: "rationCardCategory",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			// Composite Key
+			Required: true,
+			IsKey:    true,
+			Tag:      "startDate",
+			Label:    "Start Date",
+			DataType: "datetime",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			// End of the period, open-ended when empty
+			Tag:      "endDate",
+			Label:    "End Date",
+			DataType: "datetime",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			// Unit the prices are quoted per, e.g. kg
+			Required: true,
+			Tag:      "unit",
+			Label:    "Price Unit",
+			DataType: "unit",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "marketPrice",
+			Label:    "Market Price",
+			DataType: "money",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			// Price paid by the member
+			Required: true,
+			Tag:      "subsidizedPrice",
+			Label:    "Subsidized Price",
+			DataType: "money",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			// Percentage of the subsidy (market price minus subsidized price) reimbursed by the government
+			Tag:          "subsidyShare",
+			Label:        "Subsidy Share",
+			DataType:     "integer",
+			DefaultValue: 100,
+			Writers:      []string{`org2MSP`, "orgMSP"},
+			Validate: func(subsidyShare interface{}) error {
+				if toInt(subsidyShare) < 0 || toInt(subsidyShare) > 100 {
+					return errors.NewCCError("Subsidy share must be between 0 and 100", 400)
+				}
+				return nil
+			},
+		},
+	},
+}
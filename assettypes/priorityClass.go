@@ -0,0 +1,77 @@
+package assettypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+// PriorityClass configures the priority service given to the members meeting a criterion:
+// a share of the pickup slots of every distribution point reserved to priority members,
+// a larger entitlement than the ration quantity, and home delivery
+var PriorityClass = assets.AssetType{
+	Tag:         "priorityClass",
+	Label:       "Priority Class",
+	Description: "Priority service given to disabled, elderly or pregnant members",
+
+	Props: []assets.AssetProp{
+		{
+			// Primary Key
+			Required: true,
+			IsKey:    true,
+			Tag:      "criterion",
+			Label:    "Criterion",
+			DataType: "priorityCriterion",
+			Writers:  []string{`org1MSP`, "orgMSP"}, // This means only org1 can create the asset (others can edit)
+		},
+		{
+			// Age from which members are elderly, for the elderly criterion
+			Tag:      "minimumAge",
+			Label:    "Minimum Age",
+			DataType: "integer",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Percentage of the daily pickup slots (the distribution point capacity) reserved
+			// to priority members
+			Tag:          "reservedSlotShare",
+			Label:        "Reserved Slot Share",
+			DataType:     "integer",
+			DefaultValue: 0,
+			Writers:      []string{`org1MSP`, "orgMSP"},
+			Validate: func(share interface{}) error {
+				if toInt(share) < 0 || toInt(share) > 100 {
+					return errors.NewCCError("Reserved slot share must be between 0 and 100", 400)
+				}
+				return nil
+			},
+		},
+		{
+			// Percentage added to the ration quantity a member may collect
+			Tag:          "entitlementBonus",
+			Label:        "Entitlement Bonus",
+			DataType:     "integer",
+			DefaultValue: 0,
+			Writers:      []string{`org1MSP`, "orgMSP"},
+			Validate: func(bonus interface{}) error {
+				if toInt(bonus) < 0 {
+					return errors.NewCCError("Entitlement bonus cannot be negative", 400)
+				}
+				return nil
+			},
+		},
+		{
+			Tag:          "homeDelivery",
+			Label:        "Home Delivery",
+			DataType:     "boolean",
+			DefaultValue: false,
+			Writers:      []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Tag:          "active",
+			Label:        "Active",
+			DataType:     "boolean",
+			DefaultValue: true,
+			Writers:      []string{`org1MSP`, "orgMSP"},
+		},
+	},
+}
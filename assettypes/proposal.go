@@ -0,0 +1,98 @@
+package assettypes
+
+import (
+	"fmt"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+)
+
+// isValidProposalStatus checks if the given proposal status is valid
+func isValidProposalStatus(s string) bool {
+	proposalStatuses := map[string]bool{
+		"open":     true,
+		"executed": true,
+		"expired":  true,
+	}
+	return proposalStatuses[s]
+}
+
+// Proposal wraps a transaction waiting for the approvals required by its approval policy
+var Proposal = assets.AssetType{
+	Tag:         "proposal",
+	Label:       "Proposal",
+	Description: "Transaction waiting for multi-signature approval",
+
+	Props: []assets.AssetProp{
+		{
+			// Primary key: transaction which created the proposal
+			Required: true,
+			IsKey:    true,
+			Tag:      "proposalId",
+			Label:    "Proposal ID",
+			DataType: "string",
+			Writers:  []string{`$org\d*MSP`}, // Any organization can create the asset
+		},
+		{
+			Required: true,
+			Tag:      "txTag",
+			Label:    "Transaction Tag",
+			DataType: "string",
+			Writers:  []string{`$org\d*MSP`},
+		},
+		{
+			// JSON encoded arguments of the transaction
+			Required: true,
+			Tag:      "args",
+			Label:    "Arguments",
+			DataType: "string",
+			Writers:  []string{`$org\d*MSP`},
+		},
+		{
+			Required: true,
+			Tag:      "proposedBy",
+			Label:    "Proposed By",
+			DataType: "string",
+			Writers:  []string{`$org\d*MSP`},
+		},
+		{
+			// Base64 encoded identity of the proposer, on behalf of whom the transaction is executed
+			Required: true,
+			Tag:      "proposerIdentity",
+			Label:    "Proposer Identity",
+			DataType: "string",
+			Writers:  []string{`$org\d*MSP`},
+		},
+		{
+			// MSP IDs of the organizations which approved the proposal
+			Tag:      "approvals",
+			Label:    "Approvals",
+			DataType: "[]string",
+			Writers:  []string{`$org\d*MSP`},
+		},
+		{
+			Tag:      "expiryDate",
+			Label:    "Expiry Date",
+			DataType: "datetime",
+			Writers:  []string{`$org\d*MSP`},
+		},
+		{
+			Tag:          "status",
+			Label:        "Status",
+			DataType:     "string", // values: open, executed, expired
+			DefaultValue: "open",
+			Writers:      []string{`$org\d*MSP`},
+			Validate: func(status interface{}) error {
+				if !isValidProposalStatus(status.(string)) {
+					return fmt.Errorf("invalid proposal status")
+				}
+				return nil
+			},
+		},
+		{
+			Tag:      "executedDate",
+			Label:    "Executed Date",
+			DataType: "datetime",
+			Writers:  []string{`$org\d*MSP`},
+		},
+	},
+}
@@ -0,0 +1,76 @@
+package assettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// PurchaseOrder is an order of rations raised by the ministry against a distributor.
+// Every ration on chain is created against an approved purchase order.
+var PurchaseOrder = assets.AssetType{
+	Tag:         "purchaseOrder",
+	Label:       "Purchase Order",
+	Description: "Order of rations from government stock to a distributor",
+
+	Props: []assets.AssetProp{
+		{
+			// Primary key
+			Required: true,
+			IsKey:    true,
+			Tag:      "orderId",
+			Label:    "Order ID",
+			DataType: "string",
+			Writers:  []string{`org2MSP`, "orgMSP"}, // This means only org2 can create the asset (others can edit)
+		},
+		{
+			Required: true,
+			Tag:      "distributor",
+			Label:    "Distributor",
+			DataType: "->distributor",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			// Ordered and delivered quantity per ration category
+			Required: true,
+			Tag:      "lineItems",
+			Label:    "Line Items",
+			DataType: "[]purchaseOrderLine",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			// Low stock alerts this order answers
+			Tag:      "restockRequests",
+			Label:    "Restock Requests",
+			DataType: "[]->restockRequest",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:          "status",
+			Label:        "Status",
+			DataType:     "purchaseOrderStatus",
+			DefaultValue: "pending",
+			Writers:      []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "orderDate",
+			Label:    "Order Date",
+			DataType: "datetime",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "approvedBy",
+			Label:    "Approved By",
+			DataType: "string",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "approvalDate",
+			Label:    "Approval Date",
+			DataType: "datetime",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "closedDate",
+			Label:    "Closed Date",
+			DataType: "datetime",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+	},
+}
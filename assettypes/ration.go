@@ -3,6 +3,7 @@ package assettypes
 import (
 	"time"
 
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
 )
@@ -105,28 +106,51 @@ var RationAsset = assets.AssetType{
 			Label:    "distributedBy",
 			DataType: "->distributor",
 			Writers:  []string{`org2MSP`, "orgMSP"}, // This means only org2 can create the asset (others can edit)
-			Validate: func(sellerId interface{}) error {
-				if len(sellerId.(string)) < 16 {
-					return errors.NewCCError("Seller ID must be at least 16 characters", 400)
-				}
-				// check if sellerID is valid
-
-				return nil
-			},
+		},
+		// Procurement
+		{
+			// Approved purchase order the ration was created against
+			Required: true,
+			Tag:      "purchaseOrder",
+			Label:    "Purchase Order",
+			DataType: "->purchaseOrder",
+			Writers:  []string{`org2MSP`, "orgMSP"}, // This means only org2 can create the asset (others can edit)
+		},
+		{
+			// Set once the ration has been delivered against its purchase order
+			Tag:      "delivery",
+			Label:    "Delivery",
+			DataType: "->delivery",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			// Set once the ration has been added to the stock of a distribution point
+			Tag:      "stockedAt",
+			Label:    "Stocked At",
+			DataType: "->distributionPoint",
+			Writers:  []string{`org1MSP`, "orgMSP"},
 		},
 		//Quantity
 		{
 			// Composite Key
-			Required:     true,
-			IsKey:        true,
-			Tag:          "quantity",
-			Label:        "Ration Quantity",
-			DataType:     "integer",
-			Writers:      []string{`org2MSP`, "orgMSP"}, // This means only org2 can create the asset (others can edit)
-			DefaultValue: 1,
+			Required: true,
+			IsKey:    true,
+			Tag:      "quantity",
+			Label:    "Ration Quantity",
+			DataType: "quantity",                    // Value and unit, which must measure the ration category
+			Writers:  []string{`org2MSP`, "orgMSP"}, // This means only org2 can create the asset (others can edit)
 			Validate: func(quantity interface{}) error {
-				if quantity.(int) < MinRationLimit || quantity.(int) > MaxRationLimit {
-					return errors.NewCCError("Quantity must be between 1 and 100000", 400)
+				q, err := datatypes.QuantityOf(quantity)
+				if err != nil {
+					return err
+				}
+				// The limit applies to the amount in the base unit, e.g. grams
+				base, err := q.InBaseUnit()
+				if err != nil {
+					return err
+				}
+				if base.Value <= 0 || base.Value > MaxRationLimit {
+					return errors.NewCCError("Quantity must be greater than 0 and at most 100000 of its base unit", 400)
 				}
 				return nil
 			},
@@ -141,10 +165,14 @@ var RationAsset = assets.AssetType{
 			Writers:  []string{`org2MSP`, "orgMSP"}, // This means only org2 can create the asset (others can edit)
 			Validate: func(expiryDate interface{}) error {
 				// check if expiry date is valid (not in the past and not more than 60 DAYS in the future)
-				if expiryDate.(time.Time).Before(time.Now()) {
+				expiry, ok := toTime(expiryDate)
+				if !ok {
+					return errors.NewCCError("Invalid expiry date", 400)
+				}
+				if expiry.Before(time.Now()) {
 					return errors.NewCCError("Expiry date must be in the future", 400)
 				}
-				if expiryDate.(time.Time).After(time.Now().AddDate(0, 0, MinExpiryDate)) {
+				if expiry.After(time.Now().AddDate(0, 0, MinExpiryDate)) {
 					return errors.NewCCError("Expiry date must be within 60 days", 400)
 				}
 				return nil
@@ -160,7 +188,11 @@ var RationAsset = assets.AssetType{
 			Writers:  []string{`org2MSP`, "orgMSP"}, // This means only org2 can create the asset (others can edit)
 			Validate: func(mfgDate interface{}) error {
 				// check if mfg date is valid (not in the future)
-				if mfgDate.(time.Time).After(time.Now()) {
+				mfg, ok := toTime(mfgDate)
+				if !ok {
+					return errors.NewCCError("Invalid manufacturing date", 400)
+				}
+				if mfg.After(time.Now()) {
 					return errors.NewCCError("Manufacturing date must be in the past", 400)
 				}
 				return nil
@@ -176,11 +208,38 @@ var RationAsset = assets.AssetType{
 			Writers:  []string{`org2MSP`, "orgMSP"}, // This means only org2 can create the asset (others can edit)
 			Validate: func(batchNumber interface{}) error {
 				// check if batch number is valid
-				if batchNumber.(int) < 1 {
+				if toInt(batchNumber) < 1 {
 					return errors.NewCCError("Batch number must be greater than 0", 400)
 				}
 				return nil
 			},
 		},
+		// Archival: archived assets are kept on the ledger but left out of default searches
+		{
+			Tag:          "archived",
+			Label:        "Archived",
+			DataType:     "boolean",
+			DefaultValue: false,
+			Writers:      []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "archiveReason",
+			Label:    "Archive Reason",
+			DataType: "string",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			// MSP ID of the admin who archived the asset
+			Tag:      "archivedBy",
+			Label:    "Archived By",
+			DataType: "string",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "archivedDate",
+			Label:    "Archive Date",
+			DataType: "datetime",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
 	},
 }
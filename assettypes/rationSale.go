@@ -0,0 +1,145 @@
+package assettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// RationSale records the amount paid by a member for a ration bought at a distribution
+// point, and the subsidy its distributor claims for it
+var RationSale = assets.AssetType{
+	Tag:         "rationSale",
+	Label:       "Ration Sale",
+	Description: "Amount paid and subsidy claimed for a ration bought by a member",
+
+	Props: []assets.AssetProp{
+		{
+			// Primary key: buyRation transaction
+			Required: true,
+			IsKey:    true,
+			Tag:      "saleId",
+			Label:    "Sale ID",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"}, // This means only org1 can create the asset (others can edit)
+		},
+		{
+			Required: true,
+			Tag:      "member",
+			Label:    "Member",
+			DataType: "->member",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "ration",
+			Label:    "Ration",
+			DataType: "->ration",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "distributionPoint",
+			Label:    "Distribution Point",
+			DataType: "->distributionPoint",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Distributor running the distribution point, who claims the subsidy
+			Tag:      "distributor",
+			Label:    "Distributor",
+			DataType: "->distributor",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "priceSchedule",
+			Label:    "Price Schedule",
+			DataType: "->priceSchedule",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "category",
+			Label:    "Ration Category",
+			DataType: "rationCategory",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Quantity sold, in the base unit of the category
+			Required: true,
+			Tag:      "quantity",
+			Label:    "Quantity",
+			DataType: "integer",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "unit",
+			Label:    "Unit",
+			DataType: "unit",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "amountPaid",
+			Label:    "Amount Paid",
+			DataType: "money",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "subsidyClaimed",
+			Label:    "Subsidy Claimed",
+			DataType: "money",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "saleDate",
+			Label:    "Sale Date",
+			DataType: "datetime",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Month of the sale (YYYY-MM), the reimbursement period
+			Required: true,
+			Tag:      "month",
+			Label:    "Month",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Month (YYYY-MM) whose reimbursement claim covers the sale: the month of the sale,
+			// or the month it reached the ledger if that month was already claimed. Unset on
+			// the sales recorded before, which are claimed with their month.
+			Tag:      "claimMonth",
+			Label:    "Claim Month",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Whether the member or a nominee collected the ration
+			Tag:      "collectedBy",
+			Label:    "Collected By",
+			DataType: "collector",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "nominee",
+			Label:    "Nominee",
+			DataType: "->nominee",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Reimbursement claim the sale is part of, so it is claimed once
+			Tag:      "claim",
+			Label:    "Reimbursement Claim",
+			DataType: "->reimbursementClaim",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Mobile wallet payment of the amount paid, if not paid in cash
+			Tag:      "paymentReceipt",
+			Label:    "Payment Receipt",
+			DataType: "->paymentReceipt",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+	},
+}
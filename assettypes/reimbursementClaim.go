@@ -0,0 +1,118 @@
+package assettypes
+
+import "github.com/hyperledger-labs/cc-tools/assets"
+
+// ReimbursementClaim is the claim of a distributor for the subsidy of its ration sales of a
+// month. The ministry verifies it against the sales recorded on the ledger, approves it
+// in full or in part, and records the payment settling it.
+var ReimbursementClaim = assets.AssetType{
+	Tag:         "reimbursementClaim",
+	Label:       "Reimbursement Claim",
+	Description: "Claim of a distributor for the subsidy of its ration sales over a month",
+
+	Props: []assets.AssetProp{
+		{
+			// Composite Key
+			Required: true,
+			IsKey:    true,
+			Tag:      "distributor",
+			Label:    "Distributor",
+			DataType: "->distributor",
+			Writers:  []string{`org1MSP`, "orgMSP"}, // This means only org1 can create the asset (others can edit)
+		},
+		{
+			// Composite Key: month of the sales (YYYY-MM)
+			Required: true,
+			IsKey:    true,
+			Tag:      "month",
+			Label:    "Month",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "sales",
+			Label:    "Sales",
+			DataType: "[]->rationSale",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Sum of the subsidy claimed on the sales
+			Required: true,
+			Tag:      "amountClaimed",
+			Label:    "Amount Claimed",
+			DataType: "money",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Tag:          "status",
+			Label:        "Status",
+			DataType:     "reimbursementClaimStatus",
+			DefaultValue: "raised",
+			Writers:      []string{`org1MSP`, `org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "raisedDate",
+			Label:    "Raised Date",
+			DataType: "datetime",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Discrepancies between the claim and the ledger found on verification
+			Tag:      "findings",
+			Label:    "Verification Findings",
+			DataType: "[]string",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "verifiedBy",
+			Label:    "Verified By",
+			DataType: "string",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "verifiedDate",
+			Label:    "Verified Date",
+			DataType: "datetime",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "rejections",
+			Label:    "Rejected Sales",
+			DataType: "[]claimRejection",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			// Subsidy of the accepted sales, as computed from their price schedule
+			Tag:      "amountApproved",
+			Label:    "Amount Approved",
+			DataType: "money",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "decidedBy",
+			Label:    "Decided By",
+			DataType: "string",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "decisionDate",
+			Label:    "Decision Date",
+			DataType: "datetime",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			// Reference of the payment in the external payment system
+			Tag:      "paymentReference",
+			Label:    "Payment Reference",
+			DataType: "string",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "settlementDate",
+			Label:    "Settlement Date",
+			DataType: "datetime",
+			Writers:  []string{`org2MSP`, "orgMSP"},
+		},
+	},
+}
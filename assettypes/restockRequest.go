@@ -0,0 +1,97 @@
+package assettypes
+
+import (
+	"fmt"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+)
+
+// isValidRestockRequestStatus checks if the given restock request status is valid
+func isValidRestockRequestStatus(s string) bool {
+	restockRequestStatuses := map[string]bool{
+		"open":    true,
+		"ordered": true,
+		"closed":  true,
+	}
+	return restockRequestStatuses[s]
+}
+
+// RestockRequest is a low stock alert received on the procurement channel, or recorded on the
+// channel of the distribution point by the transaction which crossed the minimum level
+var RestockRequest = assets.AssetType{
+	Tag:         "restockRequest",
+	Label:       "Restock Request",
+	Description: "Low stock alert raised by a distribution point and received by the ministry",
+
+	Props: []assets.AssetProp{
+		{
+			// Primary key: transaction which raised the alert
+			Required: true,
+			IsKey:    true,
+			Tag:      "requestId",
+			Label:    "Request ID",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, `org2MSP`, "orgMSP"}, // Created by org2 from alerts, or by the org1 transactions raising them
+		},
+		{
+			Required: true,
+			Tag:      "sourceChannel",
+			Label:    "Source Channel",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, `org2MSP`, "orgMSP"},
+		},
+		{
+			// Distribution points live on the source channel, so they are not referenced as assets
+			Required: true,
+			Tag:      "distributionPointId",
+			Label:    "Distribution Point ID",
+			DataType: "string",
+			Writers:  []string{`org1MSP`, `org2MSP`, "orgMSP"},
+		},
+		{
+			Required: true,
+			Tag:      "category",
+			Label:    "Ration Category",
+			DataType: "rationCategory",
+			Writers:  []string{`org1MSP`, `org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "currentLevel",
+			Label:    "Current Stock Level",
+			DataType: "integer",
+			Writers:  []string{`org1MSP`, `org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "suggestedQuantity",
+			Label:    "Suggested Replenishment Quantity",
+			DataType: "integer",
+			Writers:  []string{`org1MSP`, `org2MSP`, "orgMSP"},
+		},
+		{
+			// Unit of the levels, the base unit of the category
+			Tag:      "unit",
+			Label:    "Unit",
+			DataType: "unit",
+			Writers:  []string{`org1MSP`, `org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:      "alertDate",
+			Label:    "Alert Date",
+			DataType: "datetime",
+			Writers:  []string{`org1MSP`, `org2MSP`, "orgMSP"},
+		},
+		{
+			Tag:          "status",
+			Label:        "Status",
+			DataType:     "string", // values: open, ordered, closed
+			DefaultValue: "open",
+			Writers:      []string{`org1MSP`, `org2MSP`, "orgMSP"},
+			Validate: func(status interface{}) error {
+				if !isValidRestockRequestStatus(status.(string)) {
+					return fmt.Errorf("invalid restock request status")
+				}
+				return nil
+			},
+		},
+	},
+}
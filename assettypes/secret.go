@@ -25,6 +25,7 @@ var Secret = assets.AssetType{
 			Tag:      "secret",
 			Label:    "Secret",
 			DataType: "string",
+			Writers:  []string{`org2MSP`, `org3MSP`, "orgMSP"},
 		},
 	},
 }
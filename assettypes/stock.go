@@ -0,0 +1,99 @@
+package assettypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+// isNegative checks if the given numeric value, as received from a request or the ledger, is negative
+func isNegative(value interface{}) bool {
+	switch v := value.(type) {
+	case int:
+		return v < 0
+	case int64:
+		return v < 0
+	case float64:
+		return v < 0
+	default:
+		return false
+	}
+}
+
+// Stock is the quantity of a ration category held at a distribution point,
+// along with the minimum level under which a low stock alert is raised.
+// Quantities and levels are amounts of the base unit of the category.
+var Stock = assets.AssetType{
+	Tag:         "stock",
+	Label:       "Stock",
+	Description: "Stock of a ration category at a distribution point",
+
+	Props: []assets.AssetProp{
+		{
+			// Composite Key
+			Required: true,
+			IsKey:    true,
+			Tag:      "distributionPoint",
+			Label:    "Distribution Point",
+			DataType: "->distributionPoint",
+			Writers:  []string{`org1MSP`, "orgMSP"}, // This means only org1 can create the asset (others can edit)
+		},
+		{
+			// Composite Key
+			Required: true,
+			IsKey:    true,
+			Tag:      "category",
+			Label:    "Ration Category",
+			DataType: "rationCategory",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			Tag:          "quantity",
+			Label:        "Quantity in Stock",
+			DataType:     "integer",
+			DefaultValue: 0,
+			Writers:      []string{`org1MSP`, "orgMSP"},
+			Validate: func(quantity interface{}) error {
+				if isNegative(quantity) {
+					return errors.NewCCError("Stock quantity cannot be negative", 400)
+				}
+				return nil
+			},
+		},
+		{
+			// Base unit of the category the quantity and levels are counted in
+			Tag:      "unit",
+			Label:    "Unit",
+			DataType: "unit",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Level under which a low stock alert is raised, 0 disables alerts
+			Tag:          "minimumLevel",
+			Label:        "Minimum Stock Level",
+			DataType:     "integer",
+			DefaultValue: 0,
+			Writers:      []string{`org1MSP`, "orgMSP"},
+			Validate: func(minimumLevel interface{}) error {
+				if isNegative(minimumLevel) {
+					return errors.NewCCError("Minimum stock level cannot be negative", 400)
+				}
+				return nil
+			},
+		},
+		{
+			// Level the stock should be replenished up to, defaults to twice the minimum level
+			Tag:      "targetLevel",
+			Label:    "Target Stock Level",
+			DataType: "integer",
+			Writers:  []string{`org1MSP`, "orgMSP"},
+		},
+		{
+			// Set when the stock went under the minimum level, so the alert is raised once per crossing
+			Tag:          "belowMinimum",
+			Label:        "Below Minimum Level",
+			DataType:     "boolean",
+			DefaultValue: false,
+			Writers:      []string{`org1MSP`, "orgMSP"},
+		},
+	},
+}
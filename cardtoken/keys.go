@@ -0,0 +1,69 @@
+package cardtoken
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Key is a published issuer public key
+type Key struct {
+	PublicKey ed25519.PublicKey
+	RetiredAt time.Time // Zero while the key is in use
+}
+
+// KeySet holds the issuer keys by key ID
+type KeySet map[string]Key
+
+// issuerKeyRecord is an issuerKey asset as read from the ledger
+type issuerKeyRecord struct {
+	KeyID       string    `json:"keyId"`
+	PublicKey   string    `json:"publicKey"`
+	RetiredDate time.Time `json:"retiredDate"`
+}
+
+// ParseKeySet reads a JSON array of issuerKey assets, such as the result of a search for
+// them, so verifiers can refresh their keys whenever they are online
+func ParseKeySet(data []byte) (KeySet, error) {
+	var records []issuerKeyRecord
+	err := json.Unmarshal(data, &records)
+	if err != nil {
+		return nil, fmt.Errorf("invalid issuer keys: %w", err)
+	}
+
+	keys := KeySet{}
+	for _, record := range records {
+		publicKey, err := DecodePublicKey(record.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("issuer key %s: %w", record.KeyID, err)
+		}
+		keys[record.KeyID] = Key{PublicKey: publicKey, RetiredAt: record.RetiredDate}
+	}
+	return keys, nil
+}
+
+// DecodePublicKey reads a base64 Ed25519 public key
+func DecodePublicKey(s string) (ed25519.PublicKey, error) {
+	publicKey, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("public key must be %d bytes of base64", ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(publicKey), nil
+}
+
+// DecodePrivateKey reads a base64 Ed25519 private key, either its seed or the full key
+func DecodePrivateKey(s string) (ed25519.PrivateKey, error) {
+	privateKey, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("private key must be base64")
+	}
+	switch len(privateKey) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(privateKey), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(privateKey), nil
+	}
+	return nil, fmt.Errorf("private key must be a %d byte seed or a %d byte key", ed25519.SeedSize, ed25519.PrivateKeySize)
+}
@@ -0,0 +1,195 @@
+// Package cardtoken encodes ration cards as compact tokens signed by their issuing org, to
+// be printed as QR codes and verified offline against the issuer keys published on the
+// ledger by rotateIssuerKey.
+//
+// A token is the base32 encoding, without padding, of its claims followed by their Ed25519
+// signature. Base32 uses only upper case letters and digits, which QR codes store in their
+// compact alphanumeric mode.
+//
+// Tokens are signed off-chain by the issuing org, whose private key never leaves it. They carry
+// an HMAC of the card holder's NID keyed with a random salt recorded with the token on the
+// ledger: NIDs are short enough for any unkeyed hash of them to be reversed, so the token alone
+// does not disclose the NID, while verifiers holding the salt match the NID the holder presents.
+package cardtoken
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version is the version of the claims layout, bumped whenever it changes
+const Version = 3
+
+var (
+	// ErrMalformed is returned for a token that cannot be decoded
+	ErrMalformed = errors.New("malformed card token")
+
+	// ErrUnknownKey is returned for a token signed with a key missing from the key set
+	ErrUnknownKey = errors.New("card token signed with an unknown key")
+
+	// ErrBadSignature is returned for a token whose signature does not match its claims
+	ErrBadSignature = errors.New("card token signature is invalid")
+
+	// ErrExpired is returned for a token past its expiry
+	ErrExpired = errors.New("card token has expired")
+
+	// ErrRetiredKey is returned for a token issued after its key was retired
+	ErrRetiredKey = errors.New("card token issued with a retired key")
+)
+
+// SaltSize is the minimum size of the salt NIDs are hashed with
+const SaltSize = 16
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Claims are the facts about a ration card a token vouches for
+type Claims struct {
+	CardNumber string
+	NIDHash    string // HashNID of the card holder's NID
+	Category   int    // Ration card category
+	IssuedAt   time.Time
+	Expiry     time.Time
+	KeyID      string // Issuer key the token is signed with
+}
+
+// HashNID hashes an NID with HMAC-SHA256 keyed with the card's salt, so tokens do not disclose
+// it, truncated to 16 bytes to keep them short
+func HashNID(nid string, salt []byte) string {
+	nid = strings.ReplaceAll(strings.ReplaceAll(strings.TrimSpace(nid), "-", ""), " ", "")
+	mac := hmac.New(sha256.New, salt)
+	mac.Write([]byte(nid))
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// MatchNID checks the NID presented by a card holder against the claims of their token
+func MatchNID(claims Claims, nid string, salt []byte) bool {
+	return hmac.Equal([]byte(HashNID(nid, salt)), []byte(claims.NIDHash))
+}
+
+// marshal writes the claims as fields separated by '|', dates as Unix seconds
+func (c Claims) marshal() ([]byte, error) {
+	fields := []string{c.CardNumber, c.NIDHash, c.KeyID}
+	for _, field := range fields {
+		if field == "" || strings.Contains(field, "|") {
+			return nil, fmt.Errorf("claim %q must be non-empty and cannot contain '|'", field)
+		}
+	}
+
+	return []byte(strings.Join([]string{
+		strconv.Itoa(Version),
+		c.CardNumber,
+		c.NIDHash,
+		strconv.Itoa(c.Category),
+		strconv.FormatInt(c.IssuedAt.Unix(), 10),
+		strconv.FormatInt(c.Expiry.Unix(), 10),
+		c.KeyID,
+	}, "|")), nil
+}
+
+// unmarshalClaims reads claims written by marshal
+func unmarshalClaims(data []byte) (Claims, error) {
+	fields := strings.Split(string(data), "|")
+	if len(fields) != 7 || fields[0] != strconv.Itoa(Version) {
+		return Claims{}, ErrMalformed
+	}
+
+	category, err := strconv.Atoi(fields[3])
+	if err != nil {
+		return Claims{}, ErrMalformed
+	}
+	issuedAt, err := strconv.ParseInt(fields[4], 10, 64)
+	if err != nil {
+		return Claims{}, ErrMalformed
+	}
+	expiry, err := strconv.ParseInt(fields[5], 10, 64)
+	if err != nil {
+		return Claims{}, ErrMalformed
+	}
+
+	return Claims{
+		CardNumber: fields[1],
+		NIDHash:    fields[2],
+		Category:   category,
+		IssuedAt:   time.Unix(issuedAt, 0).UTC(),
+		Expiry:     time.Unix(expiry, 0).UTC(),
+		KeyID:      fields[6],
+	}, nil
+}
+
+// Sign encodes the claims into a token signed with the private key of claims.KeyID.
+// It is run by the issuing org, off-chain.
+func Sign(claims Claims, key ed25519.PrivateKey) (string, error) {
+	payload, err := claims.marshal()
+	if err != nil {
+		return "", err
+	}
+	signature := ed25519.Sign(key, payload)
+	return encoding.EncodeToString(append(payload, signature...)), nil
+}
+
+// Hash returns the hex SHA-256 hash of a token, which identifies it on the ledger
+func Hash(token string) string {
+	hash := sha256.Sum256([]byte(strings.ToUpper(strings.TrimSpace(token))))
+	return hex.EncodeToString(hash[:])
+}
+
+// Parse decodes the claims of a token without checking its signature
+func Parse(token string) (Claims, error) {
+	claims, _, _, err := split(token)
+	return claims, err
+}
+
+// split decodes a token into its claims, their encoding and their signature
+func split(token string) (Claims, []byte, []byte, error) {
+	data, err := encoding.DecodeString(strings.ToUpper(strings.TrimSpace(token)))
+	if err != nil || len(data) <= ed25519.SignatureSize {
+		return Claims{}, nil, nil, ErrMalformed
+	}
+	payload, signature := data[:len(data)-ed25519.SignatureSize], data[len(data)-ed25519.SignatureSize:]
+
+	claims, err := unmarshalClaims(payload)
+	if err != nil {
+		return Claims{}, nil, nil, err
+	}
+	// Reject tokens whose claims are not written the way Sign writes them
+	canonical, err := claims.marshal()
+	if err != nil || !bytes.Equal(canonical, payload) {
+		return Claims{}, nil, nil, ErrMalformed
+	}
+
+	return claims, payload, signature, nil
+}
+
+// Verify checks a token was signed with a key of the key set, which was not retired when the
+// token was issued, and has not expired at now. It returns the claims of a valid token.
+func Verify(token string, keys KeySet, now time.Time) (Claims, error) {
+	claims, payload, signature, err := split(token)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	key, ok := keys[claims.KeyID]
+	if !ok {
+		return Claims{}, fmt.Errorf("key %s: %w", claims.KeyID, ErrUnknownKey)
+	}
+	if !ed25519.Verify(key.PublicKey, payload, signature) {
+		return Claims{}, ErrBadSignature
+	}
+	if !key.RetiredAt.IsZero() && !claims.IssuedAt.Before(key.RetiredAt) {
+		return Claims{}, fmt.Errorf("key %s retired on %s: %w", claims.KeyID, key.RetiredAt.Format(time.RFC3339), ErrRetiredKey)
+	}
+	if !now.Before(claims.Expiry) {
+		return Claims{}, ErrExpired
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,78 @@
+package cardtoken
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	salt := []byte("0123456789abcdef")
+	issuedAt := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	claims := Claims{
+		CardNumber: "RC-000123",
+		NIDHash:    HashNID("1234567890", salt),
+		Category:   2,
+		IssuedAt:   issuedAt,
+		Expiry:     issuedAt.AddDate(1, 0, 0),
+		KeyID:      "org1-2026",
+	}
+	token, err := Sign(claims, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Trim(token, "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567") != "" {
+		t.Fatalf("token %s is not QR alphanumeric", token)
+	}
+
+	now := issuedAt.AddDate(0, 1, 0)
+	tests := []struct {
+		name  string
+		token string
+		keys  KeySet
+		now   time.Time
+		want  error
+	}{
+		{"valid", token, KeySet{"org1-2026": {PublicKey: publicKey}}, now, nil},
+		{"unknown key", token, KeySet{}, now, ErrUnknownKey},
+		{"expired", token, KeySet{"org1-2026": {PublicKey: publicKey}}, claims.Expiry, ErrExpired},
+		{"retired before issue", token, KeySet{"org1-2026": {PublicKey: publicKey, RetiredAt: issuedAt}}, now, ErrRetiredKey},
+		{"retired after issue", token, KeySet{"org1-2026": {PublicKey: publicKey, RetiredAt: now}}, now, nil},
+	}
+	for _, tt := range tests {
+		got, err := Verify(tt.token, tt.keys, tt.now)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+		if err == nil && got != claims {
+			t.Errorf("%s: got claims %+v, want %+v", tt.name, got, claims)
+		}
+	}
+
+	if !MatchNID(claims, "123-456 7890", salt) || MatchNID(claims, "1234567891", salt) || MatchNID(claims, "1234567890", []byte("fedcba9876543210")) {
+		t.Error("NID hash does not match only the holder's NID with the card's salt")
+	}
+
+	if Hash(strings.ToLower(token)) != Hash(token) {
+		t.Error("token hash depends on its case")
+	}
+
+	tampered := token[:10] + flip(token[10]) + token[11:]
+	if _, err := Verify(tampered, KeySet{"org1-2026": {PublicKey: publicKey}}, now); err == nil {
+		t.Error("tampered token verified")
+	}
+}
+
+// flip replaces a base32 character by another one
+func flip(c byte) string {
+	if c == 'A' {
+		return "B"
+	}
+	return "A"
+}
@@ -2,6 +2,7 @@ package datatypes
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 
 	"github.com/hyperledger-labs/cc-tools/assets"
@@ -68,3 +69,45 @@ var rationCategory = assets.DataType{
 		return fmt.Sprint(retVal), retVal, err
 	},
 }
+
+// Label returns the display name of the category, as listed in the drop down values
+func (i RationCategory) Label() string {
+	for label, value := range rationCategory.DropDownValues {
+		if value == i {
+			return label
+		}
+	}
+	return fmt.Sprint(float64(i))
+}
+
+// categoryUnits are the units each ration category is stored in on the ledger. Quantities
+// are converted to the unit of their category, which refuses units of another dimension,
+// e.g. oil cannot be measured in kg.
+var categoryUnits = map[RationCategory]Unit{
+	RationCategoryGrains:             UnitGram,
+	RationCategoryOil:                UnitMillilitre,
+	RationCategoryPulses:             UnitGram,
+	RationCategorySugar:              UnitGram,
+	RationCategorySpices:             UnitGram,
+	RationCategoryRamadanEssentials:  UnitPacket,
+	RationCategoryPandemicEssentials: UnitPacket,
+	RationCategoryOthers:             UnitPiece,
+}
+
+// BaseUnit returns the unit the quantities of the category are stored in
+func (i RationCategory) BaseUnit() Unit {
+	return categoryUnits[i]
+}
+
+// BaseAmount converts a quantity of the category to a whole amount of its base unit
+func (i RationCategory) BaseAmount(q Quantity) (int, errors.ICCError) {
+	// Quantities without unit were written in the base unit of their category
+	if q.Unit == "" {
+		q.Unit = i.BaseUnit()
+	}
+	converted, err := q.ConvertTo(i.BaseUnit())
+	if err != nil {
+		return 0, errors.NewCCError(fmt.Sprintf("%s cannot be measured in %s", i.Label(), q.Unit), 400)
+	}
+	return int(math.Round(converted.Value)), nil
+}
@@ -3,25 +3,50 @@ package datatypes
 import (
 	"encoding/json"
 	"regexp"
+	"strings"
 
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
 )
 
+// Address is a Bangladeshi postal address. Its administrative areas must be registered
+// as adminArea assets, which is checked by the transactions writing addresses.
 type Address struct {
-	Street     string `json:"street"`
-	City       string `json:"city"`
-	State      string `json:"state"`
-	PostalCode string `json:"postalCode"`
-	Country    string `json:"country"`
+	AddressLine string `json:"addressLine"`     // House, road, village or ward
+	Union       string `json:"union,omitempty"` // Union parishad, left empty in urban areas
+	Upazila     string `json:"upazila"`
+	District    string `json:"district"`
+	Division    string `json:"division"`
+	PostCode    string `json:"postCode"`
 }
 
+// AreaNames returns the names of the administrative areas of the address, from the division down
+func (a Address) AreaNames() []string {
+	names := []string{a.Division, a.District, a.Upazila}
+	if a.Union != "" {
+		names = append(names, a.Union)
+	}
+	return names
+}
+
+var postCodeRegex = regexp.MustCompile(`^\d{4}$`)
+
 var address = assets.DataType{
 	AcceptedFormats: []string{"@object"},
-	Description:     "A JSON string representing an address with fields 'street', 'city', 'state', 'postalCode', and 'country'.",
+	Description:     "A JSON string representing a Bangladeshi address with fields 'addressLine', 'union', 'upazila', 'district', 'division' and a 4-digit 'postCode'.",
 	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
-		dataStr, ok := data.(string)
-		if !ok {
+		var dataStr string
+		switch v := data.(type) {
+		case string:
+			dataStr = v
+		case Address, map[string]interface{}:
+			// Already parsed value, read back from the ledger on updates or sent as a JSON object
+			dataBytes, err := json.Marshal(v)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "failed to encode address", 400)
+			}
+			dataStr = string(dataBytes)
+		default:
 			return "", nil, errors.NewCCError("property must be a JSON string", 400)
 		}
 
@@ -31,25 +56,30 @@ var address = assets.DataType{
 			return "", nil, errors.WrapErrorWithStatus(err, "invalid JSON format", 400)
 		}
 
-		if addr.Street == "" {
-			return "", nil, errors.NewCCError("street is required", 400)
+		if addr.AddressLine == "" {
+			return "", nil, errors.NewCCError("addressLine is required", 400)
+		}
+
+		if addr.Division == "" {
+			return "", nil, errors.NewCCError("division is required", 400)
 		}
 
-		if addr.City == "" {
-			return "", nil, errors.NewCCError("city is required", 400)
+		if addr.District == "" {
+			return "", nil, errors.NewCCError("district is required", 400)
 		}
 
-		if addr.State == "" {
-			return "", nil, errors.NewCCError("state is required", 400)
+		if addr.Upazila == "" {
+			return "", nil, errors.NewCCError("upazila is required", 400)
 		}
 
-		postalCodeRegex := regexp.MustCompile(`^\d{5}(-\d{4})?$`)
-		if !postalCodeRegex.MatchString(addr.PostalCode) {
-			return "", nil, errors.NewCCError("invalid postalCode format", 400)
+		for _, name := range addr.AreaNames() {
+			if strings.Contains(name, "/") {
+				return "", nil, errors.NewCCError("administrative area names must not contain '/'", 400)
+			}
 		}
 
-		if addr.Country == "" {
-			return "", nil, errors.NewCCError("country is required", 400)
+		if !postCodeRegex.MatchString(addr.PostCode) {
+			return "", nil, errors.NewCCError("invalid postCode format, expected 4 digits", 400)
 		}
 
 		return dataStr, addr, nil
@@ -0,0 +1,77 @@
+package datatypes
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+// AdminAreaLevel is the level of a Bangladeshi administrative area, from the division down
+type AdminAreaLevel float64
+
+const (
+	AdminAreaLevelDivision AdminAreaLevel = iota
+	AdminAreaLevelDistrict
+	AdminAreaLevelUpazila
+	AdminAreaLevelUnion
+)
+
+// CheckType checks if the given value is defined as valid AdminAreaLevel consts
+func (i AdminAreaLevel) CheckType() errors.ICCError {
+	switch i {
+	case AdminAreaLevelDivision, AdminAreaLevelDistrict, AdminAreaLevelUpazila, AdminAreaLevelUnion:
+		return nil
+	default:
+		return errors.NewCCError("invalid type", 400)
+	}
+}
+
+// AddressField returns the address field holding the name of an area of this level
+func (i AdminAreaLevel) AddressField() string {
+	switch i {
+	case AdminAreaLevelDivision:
+		return "division"
+	case AdminAreaLevelDistrict:
+		return "district"
+	case AdminAreaLevelUpazila:
+		return "upazila"
+	default:
+		return "union"
+	}
+}
+
+var adminAreaLevel = assets.DataType{
+	AcceptedFormats: []string{"string"},
+	DropDownValues: map[string]interface{}{
+		"Division": AdminAreaLevelDivision,
+		"District": AdminAreaLevelDistrict,
+		"Upazila":  AdminAreaLevelUpazila,
+		"Union":    AdminAreaLevelUnion,
+	},
+	Description: "A string representing the level of an administrative area.",
+	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
+		var dataVal float64
+		switch v := data.(type) {
+		case float64:
+			dataVal = v
+		case int:
+			dataVal = float64(v)
+		case AdminAreaLevel:
+			dataVal = float64(v)
+		case string:
+			var err error
+			dataVal, err = strconv.ParseFloat(v, 64)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "invalid number format", 400)
+			}
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+
+		retVal := (AdminAreaLevel)(dataVal)
+		err := retVal.CheckType()
+		return fmt.Sprint(retVal), retVal, err
+	},
+}
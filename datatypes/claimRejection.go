@@ -0,0 +1,59 @@
+package datatypes
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+// ClaimRejection is a sale of a reimbursement claim rejected by the ministry, with the reason why
+type ClaimRejection struct {
+	Sale   string `json:"sale"` // Key of the rationSale
+	Reason string `json:"reason"`
+}
+
+var claimRejection = assets.DataType{
+	AcceptedFormats: []string{"@object"},
+	Description:     "A JSON object representing a rejected sale of a reimbursement claim with fields 'sale' (rationSale key) and 'reason'.",
+	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
+		var rejection ClaimRejection
+		switch v := data.(type) {
+		case ClaimRejection:
+			rejection = v
+		case string:
+			err := json.Unmarshal([]byte(v), &rejection)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "invalid JSON format", 400)
+			}
+		case map[string]interface{}:
+			rejectionJSON, err := json.Marshal(v)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "invalid claim rejection", 400)
+			}
+			err = json.Unmarshal(rejectionJSON, &rejection)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "invalid claim rejection", 400)
+			}
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+
+		if !strings.HasPrefix(rejection.Sale, "rationSale:") {
+			return "", nil, errors.NewCCError("sale must be the key of a ration sale", 400)
+		}
+
+		rejection.Reason = strings.TrimSpace(rejection.Reason)
+		if rejection.Reason == "" {
+			return "", nil, errors.NewCCError("reason is required", 400)
+		}
+
+		rejectionJSON, nerr := json.Marshal(rejection)
+		if nerr != nil {
+			return "", nil, errors.WrapErrorWithStatus(nerr, "failed to encode claim rejection", 500)
+		}
+
+		return string(rejectionJSON), rejection, nil
+	},
+}
@@ -0,0 +1,43 @@
+package datatypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+// Collector is who collected a ration for a member: the member or a nominee
+type Collector string
+
+const (
+	CollectorPrincipal Collector = "principal"
+	CollectorNominee   Collector = "nominee"
+)
+
+var collector = assets.DataType{
+	AcceptedFormats: []string{"string"},
+	DropDownValues: map[string]interface{}{
+		"Principal": CollectorPrincipal,
+		"Nominee":   CollectorNominee,
+	},
+	Description: "A string representing who collected a ration for a member.",
+	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
+		var dataVal string
+		switch v := data.(type) {
+		case string:
+			dataVal = v
+		case Collector:
+			dataVal = string(v)
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+
+		collected := Collector(dataVal)
+		switch collected {
+		case CollectorPrincipal, CollectorNominee:
+			break
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+		return dataVal, collected, nil
+	},
+}
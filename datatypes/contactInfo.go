@@ -18,8 +18,18 @@ var contactInfo = assets.DataType{
 	AcceptedFormats: []string{"@object"},
 	Description:     "A JSON string representing contact information with fields 'name', 'email', and 'phone'.",
 	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
-		dataStr, ok := data.(string)
-		if !ok {
+		var dataStr string
+		switch v := data.(type) {
+		case string:
+			dataStr = v
+		case ContactInfo, map[string]interface{}:
+			// Already parsed value, read back from the ledger on updates or sent as a JSON object
+			dataBytes, err := json.Marshal(v)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "failed to encode contact information", 400)
+			}
+			dataStr = string(dataBytes)
+		default:
 			return "", nil, errors.NewCCError("property must be a JSON string", 400)
 		}
 
@@ -14,9 +14,24 @@ var CustomDataTypes = map[string]assets.DataType{
 	"rationDistributionHistory": rationDistributionHistory,
 	"rationCardCategory":        rationCardCategory,
 	"address":                   address,
+	"adminAreaLevel":            adminAreaLevel,
 	"rationCardStatus":          rationCardStatus,
 	"operatingHours":            operatingHours,
 	"inspectionStatus":          inspectionStatus,
 	"rationTransaction":         rationTransaction,
 	"rationCategory":            rationCategory,
+	"purchaseOrderStatus":       purchaseOrderStatus,
+	"purchaseOrderLine":         purchaseOrderLine,
+	"quantity":                  quantity,
+	"unit":                      unit,
+	"money":                     money,
+	"reimbursementClaimStatus":  reimbursementClaimStatus,
+	"claimRejection":            claimRejection,
+	"paymentReceipt":            paymentReceipt,
+	"pickupBookingStatus":       pickupBookingStatus,
+	"issuerKeyStatus":           issuerKeyStatus,
+	"offlineDistribution":       offlineDistribution,
+	"offlineExceptionReason":    offlineExceptionReason,
+	"collector":                 collector,
+	"priorityCriterion":         priorityCriterion,
 }
@@ -0,0 +1,42 @@
+package datatypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+type IssuerKeyStatus string
+
+const (
+	IssuerKeyStatusActive  IssuerKeyStatus = "active"
+	IssuerKeyStatusRetired IssuerKeyStatus = "retired"
+)
+
+var issuerKeyStatus = assets.DataType{
+	AcceptedFormats: []string{"string"},
+	DropDownValues: map[string]interface{}{
+		"Active":  IssuerKeyStatusActive,
+		"Retired": IssuerKeyStatusRetired,
+	},
+	Description: "A string representing the issuer key status.",
+	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
+		var dataVal string
+		switch v := data.(type) {
+		case string:
+			dataVal = v
+		case IssuerKeyStatus:
+			dataVal = string(v)
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+
+		status := IssuerKeyStatus(dataVal)
+		switch status {
+		case IssuerKeyStatusActive, IssuerKeyStatusRetired:
+			break
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+		return dataVal, status, nil
+	},
+}
@@ -0,0 +1,111 @@
+package datatypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+// Money is an amount of Bangladeshi taka (BDT), counted in poisha (1/100 taka) so that
+// amounts add up exactly. It is written as a decimal string, e.g. "12.50".
+type Money int64
+
+var moneyPattern = regexp.MustCompile(`^-?\d+(\.\d{1,2})?$`)
+
+// ParseMoney reads a decimal amount of taka with at most two decimals
+func ParseMoney(s string) (Money, errors.ICCError) {
+	s = strings.TrimSpace(s)
+	if !moneyPattern.MatchString(s) {
+		return 0, errors.NewCCError(fmt.Sprintf("invalid amount %q, expected taka with at most two decimals, e.g. \"12.50\"", s), 400)
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	taka, poisha, _ := strings.Cut(strings.TrimPrefix(s, "-"), ".")
+	poisha = (poisha + "00")[:2]
+	value, err := strconv.ParseInt(taka+poisha, 10, 64)
+	if err != nil {
+		return 0, errors.WrapErrorWithStatus(err, fmt.Sprintf("amount %q is out of range", s), 400)
+	}
+	if negative {
+		value = -value
+	}
+	return Money(value), nil
+}
+
+// String formats the amount with two decimals, e.g. "12.50"
+func (m Money) String() string {
+	sign, value := "", int64(m)
+	if value < 0 {
+		sign, value = "-", -value
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, value/100, value%100)
+}
+
+// MulRatio multiplies the amount by num/den (den > 0), rounding half away from zero to the poisha
+func (m Money) MulRatio(num, den int64) Money {
+	product := int64(m) * num
+	if product < 0 {
+		return Money(-((-product + den/2) / den))
+	}
+	return Money((product + den/2) / den)
+}
+
+// MarshalJSON writes the amount as a decimal string, so that it is never read as a float
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON reads an amount written as a decimal string
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("amount must be a decimal string: %w", err)
+	}
+	value, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+	*m = value
+	return nil
+}
+
+// MoneyOf reads an amount as sent in a request or read back from the ledger
+func MoneyOf(data interface{}) (Money, errors.ICCError) {
+	_, parsed, err := money.Parse(data)
+	if err != nil {
+		return 0, err
+	}
+	return parsed.(Money), nil
+}
+
+var money = assets.DataType{
+	AcceptedFormats: []string{"string"},
+	Description:     "A decimal string representing an amount of taka (BDT) with at most two decimals, e.g. \"12.50\".",
+	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
+		var amount Money
+		switch v := data.(type) {
+		case Money:
+			amount = v
+		case string:
+			var err errors.ICCError
+			amount, err = ParseMoney(v)
+			if err != nil {
+				return "", nil, err
+			}
+		default:
+			// Numbers are refused, as they would go through float64
+			return "", nil, errors.NewCCError("amount must be a decimal string, e.g. \"12.50\"", 400)
+		}
+
+		if amount < 0 {
+			return "", nil, errors.NewCCError("amount cannot be negative", 400)
+		}
+
+		return amount.String(), amount, nil
+	},
+}
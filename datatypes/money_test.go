@@ -0,0 +1,56 @@
+package datatypes
+
+import "testing"
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Money
+		wantErr bool
+	}{
+		{"12.50", 1250, false},
+		{"12.5", 1250, false},
+		{"12", 1200, false},
+		{"0.05", 5, false},
+		{" 3.20 ", 320, false},
+		{"-4.75", -475, false},
+		{"12.505", 0, true},
+		{"12.", 0, true},
+		{".50", 0, true},
+		{"1,000.00", 0, true},
+		{"taka", 0, true},
+		{"", 0, true},
+		{"99999999999999999999", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%q: got error %v, want error %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%q: got %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestMulRatio(t *testing.T) {
+	tests := []struct {
+		name     string
+		m        Money
+		num, den int64
+		want     Money
+	}{
+		{"whole", 1000, 3, 4, 750},
+		{"rounded to the nearest poisha", 1001, 1, 3, 334},
+		{"rounded half up", 5, 1, 2, 3},
+		{"negative rounded half away from zero", -5, 1, 2, -3},
+		{"zero ratio", 1250, 0, 7, 0},
+		{"larger than one", 1250, 5, 2, 3125},
+	}
+	for _, tt := range tests {
+		if got := tt.m.MulRatio(tt.num, tt.den); got != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
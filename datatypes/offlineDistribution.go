@@ -0,0 +1,96 @@
+package datatypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+// OfflineDistribution is a ration handed out while a distribution point was offline, as
+// recorded by its device
+type OfflineDistribution struct {
+	Sequence         int       `json:"sequence"`   // Position of the record on the device, from 1
+	RecordedAt       time.Time `json:"recordedAt"` // Device clock
+	RationCardNumber string    `json:"rationCardNumber"`
+	Ration           string    `json:"ration"` // Key of the ration
+	Quantity         Quantity  `json:"quantity"`
+	CollectorNID     string    `json:"collectorNid,omitempty"` // Nominee who collected, empty for the member
+}
+
+// OfflineBatchMessage is the message a device signs for a batch of distributions: its device
+// ID, then a line per distribution with its fields separated by '|'. The collector NID is
+// only appended when set, so devices unaware of nominees sign the same message.
+func OfflineBatchMessage(deviceId string, distributions []OfflineDistribution) []byte {
+	lines := []string{deviceId}
+	for _, d := range distributions {
+		fields := []string{
+			strconv.Itoa(d.Sequence),
+			d.RecordedAt.UTC().Format(time.RFC3339),
+			d.RationCardNumber,
+			d.Ration,
+			strconv.FormatFloat(d.Quantity.Value, 'f', -1, 64),
+			string(d.Quantity.Unit),
+		}
+		if d.CollectorNID != "" {
+			fields = append(fields, d.CollectorNID)
+		}
+		lines = append(lines, strings.Join(fields, "|"))
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+var offlineDistribution = assets.DataType{
+	AcceptedFormats: []string{"@object"},
+	Description:     "A JSON object representing a distribution recorded offline with fields 'sequence', 'recordedAt', 'rationCardNumber', 'ration' (ration key), 'quantity' and optionally 'collectorNid'.",
+	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
+		var distribution OfflineDistribution
+		switch v := data.(type) {
+		case OfflineDistribution:
+			distribution = v
+		case string:
+			err := json.Unmarshal([]byte(v), &distribution)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "invalid JSON format", 400)
+			}
+		case map[string]interface{}:
+			distributionJSON, err := json.Marshal(v)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "invalid offline distribution", 400)
+			}
+			err = json.Unmarshal(distributionJSON, &distribution)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "invalid offline distribution", 400)
+			}
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+
+		if distribution.Sequence < 1 {
+			return "", nil, errors.NewCCError("sequence must be greater than 0", 400)
+		}
+		if distribution.RecordedAt.IsZero() {
+			return "", nil, errors.NewCCError("recordedAt is required", 400)
+		}
+		if distribution.RationCardNumber == "" {
+			return "", nil, errors.NewCCError("rationCardNumber is required", 400)
+		}
+		if !strings.HasPrefix(distribution.Ration, "ration:") {
+			return "", nil, errors.NewCCError(fmt.Sprintf("ration of record %d must be the key of a ration", distribution.Sequence), 400)
+		}
+		if err := distribution.Quantity.Unit.CheckType(); err != nil {
+			return "", nil, err
+		}
+
+		distributionJSON, nerr := json.Marshal(distribution)
+		if nerr != nil {
+			return "", nil, errors.WrapErrorWithStatus(nerr, "failed to encode offline distribution", 500)
+		}
+
+		return string(distributionJSON), distribution, nil
+	},
+}
@@ -0,0 +1,46 @@
+package datatypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+type OfflineExceptionReason string
+
+const (
+	OfflineExceptionReasonOverQuota    OfflineExceptionReason = "overQuota"
+	OfflineExceptionReasonDoublePickup OfflineExceptionReason = "doublePickup"
+	OfflineExceptionReasonOutOfStock   OfflineExceptionReason = "outOfStock"
+	OfflineExceptionReasonInvalid      OfflineExceptionReason = "invalid"
+)
+
+var offlineExceptionReason = assets.DataType{
+	AcceptedFormats: []string{"string"},
+	DropDownValues: map[string]interface{}{
+		"Over Quota":    OfflineExceptionReasonOverQuota,
+		"Double Pickup": OfflineExceptionReasonDoublePickup,
+		"Out of Stock":  OfflineExceptionReasonOutOfStock,
+		"Invalid":       OfflineExceptionReasonInvalid,
+	},
+	Description: "A string representing why an offline distribution could not be replayed.",
+	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
+		var dataVal string
+		switch v := data.(type) {
+		case string:
+			dataVal = v
+		case OfflineExceptionReason:
+			dataVal = string(v)
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+
+		reason := OfflineExceptionReason(dataVal)
+		switch reason {
+		case OfflineExceptionReasonOverQuota, OfflineExceptionReasonDoublePickup, OfflineExceptionReasonOutOfStock, OfflineExceptionReasonInvalid:
+			break
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+		return dataVal, reason, nil
+	},
+}
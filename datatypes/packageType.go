@@ -93,3 +93,13 @@ var packageType = assets.DataType{
 		return fmt.Sprint(retVal), retVal, err
 	},
 }
+
+// Label returns the display name of the package type, as listed in the drop down values
+func (b PackageType) Label() string {
+	for label, value := range packageType.DropDownValues {
+		if value == b {
+			return label
+		}
+	}
+	return fmt.Sprint(float64(b))
+}
@@ -0,0 +1,79 @@
+package datatypes
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+// MFSProvider is a mobile financial service members pay their co-payment with
+type MFSProvider string
+
+const (
+	MFSProviderBKash  MFSProvider = "bKash"
+	MFSProviderNagad  MFSProvider = "Nagad"
+	MFSProviderRocket MFSProvider = "Rocket"
+	MFSProviderUpay   MFSProvider = "Upay"
+)
+
+// PaymentReceipt is the receipt of a mobile wallet payment, identified by its provider and
+// the transaction reference the provider gave it
+type PaymentReceipt struct {
+	Provider  MFSProvider `json:"provider"`
+	Reference string      `json:"reference"`
+	Amount    Money       `json:"amount"`
+}
+
+var paymentReceipt = assets.DataType{
+	AcceptedFormats: []string{"@object"},
+	Description:     "A JSON object representing a mobile wallet payment receipt with fields 'provider' (bKash, Nagad, Rocket or Upay), 'reference' and 'amount'.",
+	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
+		var receipt PaymentReceipt
+		switch v := data.(type) {
+		case PaymentReceipt:
+			receipt = v
+		case string:
+			err := json.Unmarshal([]byte(v), &receipt)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "invalid JSON format", 400)
+			}
+		case map[string]interface{}:
+			receiptJSON, err := json.Marshal(v)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "invalid payment receipt", 400)
+			}
+			err = json.Unmarshal(receiptJSON, &receipt)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "invalid payment receipt", 400)
+			}
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+
+		switch receipt.Provider {
+		case MFSProviderBKash, MFSProviderNagad, MFSProviderRocket, MFSProviderUpay:
+			break
+		default:
+			return "", nil, errors.NewCCError("provider must be one of bKash, Nagad, Rocket or Upay", 400)
+		}
+
+		// Providers print references in either case, so they are compared in upper case
+		receipt.Reference = strings.ToUpper(strings.TrimSpace(receipt.Reference))
+		if receipt.Reference == "" {
+			return "", nil, errors.NewCCError("reference is required", 400)
+		}
+
+		if receipt.Amount <= 0 {
+			return "", nil, errors.NewCCError("amount must be greater than 0", 400)
+		}
+
+		receiptJSON, nerr := json.Marshal(receipt)
+		if nerr != nil {
+			return "", nil, errors.WrapErrorWithStatus(nerr, "failed to encode payment receipt", 500)
+		}
+
+		return string(receiptJSON), receipt, nil
+	},
+}
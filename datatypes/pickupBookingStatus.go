@@ -0,0 +1,46 @@
+package datatypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+type PickupBookingStatus string
+
+const (
+	PickupBookingStatusBooked    PickupBookingStatus = "booked"
+	PickupBookingStatusVerified  PickupBookingStatus = "verified"
+	PickupBookingStatusCollected PickupBookingStatus = "collected"
+	PickupBookingStatusLocked    PickupBookingStatus = "locked"
+)
+
+var pickupBookingStatus = assets.DataType{
+	AcceptedFormats: []string{"string"},
+	DropDownValues: map[string]interface{}{
+		"Booked":    PickupBookingStatusBooked,
+		"Verified":  PickupBookingStatusVerified,
+		"Collected": PickupBookingStatusCollected,
+		"Locked":    PickupBookingStatusLocked,
+	},
+	Description: "A string representing the pickup booking status.",
+	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
+		var dataVal string
+		switch v := data.(type) {
+		case string:
+			dataVal = v
+		case PickupBookingStatus:
+			dataVal = string(v)
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+
+		status := PickupBookingStatus(dataVal)
+		switch status {
+		case PickupBookingStatusBooked, PickupBookingStatusVerified, PickupBookingStatusCollected, PickupBookingStatusLocked:
+			break
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+		return dataVal, status, nil
+	},
+}
@@ -0,0 +1,45 @@
+package datatypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+// PriorityCriterion is what makes a member eligible to a priority class
+type PriorityCriterion string
+
+const (
+	PriorityCriterionDisability PriorityCriterion = "disability" // disabilityStatus is set
+	PriorityCriterionElderly    PriorityCriterion = "elderly"    // Age from dateOfBirth over the class minimum age
+	PriorityCriterionPregnancy  PriorityCriterion = "pregnancy"  // pregnant is set
+)
+
+var priorityCriterion = assets.DataType{
+	AcceptedFormats: []string{"string"},
+	DropDownValues: map[string]interface{}{
+		"Disability": PriorityCriterionDisability,
+		"Elderly":    PriorityCriterionElderly,
+		"Pregnancy":  PriorityCriterionPregnancy,
+	},
+	Description: "A string representing the criterion of a priority class.",
+	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
+		var dataVal string
+		switch v := data.(type) {
+		case string:
+			dataVal = v
+		case PriorityCriterion:
+			dataVal = string(v)
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+
+		criterion := PriorityCriterion(dataVal)
+		switch criterion {
+		case PriorityCriterionDisability, PriorityCriterionElderly, PriorityCriterionPregnancy:
+			break
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+		return dataVal, criterion, nil
+	},
+}
@@ -0,0 +1,94 @@
+package datatypes
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+// PurchaseOrderLine is the quantity of a ration category ordered, along with the quantity allocated
+// to rations created against the order and the quantity delivered so far.
+// Lines may be sent in any unit of the category, they are stored in its base unit.
+type PurchaseOrderLine struct {
+	Category  RationCategory `json:"category"`
+	Quantity  float64        `json:"quantity"`
+	Allocated float64        `json:"allocated"`
+	Delivered float64        `json:"delivered"`
+	Unit      Unit           `json:"unit"`
+}
+
+var purchaseOrderLine = assets.DataType{
+	AcceptedFormats: []string{"@object"},
+	Description:     "A JSON object representing a purchase order line item with fields 'category', 'quantity', 'allocated', 'delivered' and 'unit'.",
+	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
+		var line PurchaseOrderLine
+		switch v := data.(type) {
+		case PurchaseOrderLine:
+			line = v
+		case string:
+			err := json.Unmarshal([]byte(v), &line)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "invalid JSON format", 400)
+			}
+		case map[string]interface{}:
+			lineJSON, err := json.Marshal(v)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "invalid purchase order line", 400)
+			}
+			err = json.Unmarshal(lineJSON, &line)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "invalid purchase order line", 400)
+			}
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+
+		err := line.Category.CheckType()
+		if err != nil {
+			return "", nil, errors.WrapError(err, "invalid category")
+		}
+
+		// Lines written before units were introduced are in the base unit of their category
+		if line.Unit == "" {
+			line.Unit = line.Category.BaseUnit()
+		}
+		quantity, err := line.Category.BaseAmount(Quantity{Value: line.Quantity, Unit: line.Unit})
+		if err != nil {
+			return "", nil, err
+		}
+		delivered, err := line.Category.BaseAmount(Quantity{Value: line.Delivered, Unit: line.Unit})
+		if err != nil {
+			return "", nil, err
+		}
+		allocated, err := line.Category.BaseAmount(Quantity{Value: line.Allocated, Unit: line.Unit})
+		if err != nil {
+			return "", nil, err
+		}
+		line.Quantity, line.Allocated, line.Delivered, line.Unit = float64(quantity), float64(allocated), float64(delivered), line.Category.BaseUnit()
+
+		// Lines written before allocations were tracked have at least their deliveries allocated
+		if line.Allocated < line.Delivered {
+			line.Allocated = line.Delivered
+		}
+
+		if line.Quantity <= 0 {
+			return "", nil, errors.NewCCError("quantity must be greater than 0", 400)
+		}
+
+		if line.Delivered < 0 || line.Delivered > line.Quantity {
+			return "", nil, errors.NewCCError("delivered quantity must be between 0 and the ordered quantity", 400)
+		}
+
+		if line.Allocated > line.Quantity {
+			return "", nil, errors.NewCCError("allocated quantity must not exceed the ordered quantity", 400)
+		}
+
+		lineJSON, nerr := json.Marshal(line)
+		if nerr != nil {
+			return "", nil, errors.WrapErrorWithStatus(nerr, "failed to encode purchase order line", 500)
+		}
+
+		return string(lineJSON), line, nil
+	},
+}
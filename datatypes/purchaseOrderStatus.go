@@ -0,0 +1,51 @@
+package datatypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusPending            PurchaseOrderStatus = "pending"
+	PurchaseOrderStatusApproved           PurchaseOrderStatus = "approved"
+	PurchaseOrderStatusPartiallyDelivered PurchaseOrderStatus = "partiallyDelivered"
+	PurchaseOrderStatusClosed             PurchaseOrderStatus = "closed"
+)
+
+// AcceptsDeliveries tells if rations can still be delivered against an order in this status
+func (s PurchaseOrderStatus) AcceptsDeliveries() bool {
+	return s == PurchaseOrderStatusApproved || s == PurchaseOrderStatusPartiallyDelivered
+}
+
+var purchaseOrderStatus = assets.DataType{
+	AcceptedFormats: []string{"string"},
+	DropDownValues: map[string]interface{}{
+		"Pending":             PurchaseOrderStatusPending,
+		"Approved":            PurchaseOrderStatusApproved,
+		"Partially Delivered": PurchaseOrderStatusPartiallyDelivered,
+		"Closed":              PurchaseOrderStatusClosed,
+	},
+	Description: "A string representing the purchase order status.",
+	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
+		var dataVal string
+		switch v := data.(type) {
+		case string:
+			dataVal = v
+		case PurchaseOrderStatus:
+			dataVal = string(v)
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+
+		status := PurchaseOrderStatus(dataVal)
+		switch status {
+		case PurchaseOrderStatusPending, PurchaseOrderStatusApproved, PurchaseOrderStatusPartiallyDelivered, PurchaseOrderStatusClosed:
+			break
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+		return dataVal, status, nil
+	},
+}
@@ -0,0 +1,205 @@
+package datatypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+// Unit is a unit of measure of ration quantities
+type Unit string
+
+const (
+	UnitKilogram   Unit = "kg"
+	UnitGram       Unit = "g"
+	UnitLitre      Unit = "L"
+	UnitMillilitre Unit = "mL"
+	UnitPiece      Unit = "piece"
+	UnitPacket     Unit = "packet"
+)
+
+// unitScale is the base unit of the dimension of a unit and the number of base units it holds.
+// Units with different base units measure different things and cannot be converted into each other.
+type unitScale struct {
+	base   Unit
+	factor float64
+}
+
+var unitScales = map[Unit]unitScale{
+	UnitKilogram:   {UnitGram, 1000},
+	UnitGram:       {UnitGram, 1},
+	UnitLitre:      {UnitMillilitre, 1000},
+	UnitMillilitre: {UnitMillilitre, 1},
+	UnitPiece:      {UnitPiece, 1},
+	UnitPacket:     {UnitPacket, 1},
+}
+
+// CheckType checks if the given value is defined as valid Unit consts
+func (u Unit) CheckType() errors.ICCError {
+	if _, ok := unitScales[u]; !ok {
+		return errors.NewCCError(fmt.Sprintf("invalid unit %q", string(u)), 400)
+	}
+	return nil
+}
+
+var unit = assets.DataType{
+	AcceptedFormats: []string{"string"},
+	DropDownValues: map[string]interface{}{
+		"Kilogram":   UnitKilogram,
+		"Gram":       UnitGram,
+		"Litre":      UnitLitre,
+		"Millilitre": UnitMillilitre,
+		"Piece":      UnitPiece,
+		"Packet":     UnitPacket,
+	},
+	Description: "A string representing a unit of measure.",
+	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
+		var retVal Unit
+		switch v := data.(type) {
+		case string:
+			retVal = Unit(v)
+		case Unit:
+			retVal = v
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+
+		err := retVal.CheckType()
+		return string(retVal), retVal, err
+	},
+}
+
+// Quantity is an amount of rations in a unit of measure. Ration quantities written before
+// units were introduced are bare integers in the base unit of the ration category, read as
+// quantities without unit.
+type Quantity struct {
+	Value float64 `json:"value"`
+	Unit  Unit    `json:"unit"`
+}
+
+// MarshalJSON writes quantities without unit back as bare amounts, so that the key of the
+// rations holding them does not change
+func (q Quantity) MarshalJSON() ([]byte, error) {
+	if q.Unit == "" {
+		return json.Marshal(q.Value)
+	}
+	type quantityObject Quantity
+	return json.Marshal(quantityObject(q))
+}
+
+// ConvertTo converts the quantity to another unit, refusing units of another dimension
+func (q Quantity) ConvertTo(to Unit) (Quantity, errors.ICCError) {
+	from, ok := unitScales[q.Unit]
+	if !ok {
+		return Quantity{}, q.Unit.CheckType()
+	}
+	scale, ok := unitScales[to]
+	if !ok {
+		return Quantity{}, to.CheckType()
+	}
+	if from.base != scale.base {
+		return Quantity{}, errors.NewCCError(fmt.Sprintf("cannot convert %s to %s", q.Unit, to), 400)
+	}
+	return Quantity{Value: q.Value * from.factor / scale.factor, Unit: to}, nil
+}
+
+// InBaseUnit converts the quantity to the base unit of its dimension, e.g. kg to g.
+// Quantities without unit are already in their base unit.
+func (q Quantity) InBaseUnit() (Quantity, errors.ICCError) {
+	if q.Unit == "" {
+		return q, nil
+	}
+	scale, ok := unitScales[q.Unit]
+	if !ok {
+		return Quantity{}, q.Unit.CheckType()
+	}
+	return q.ConvertTo(scale.base)
+}
+
+// Add returns the sum of two quantities in the unit of q, refusing quantities of another dimension
+func (q Quantity) Add(other Quantity) (Quantity, errors.ICCError) {
+	converted, err := other.ConvertTo(q.Unit)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return Quantity{Value: q.Value + converted.Value, Unit: q.Unit}, nil
+}
+
+// String formats the quantity for messages, e.g. "2.5 kg"
+func (q Quantity) String() string {
+	return fmt.Sprintf("%g %s", q.Value, q.Unit)
+}
+
+// QuantityOf reads a quantity as sent in a request or read back from the ledger
+func QuantityOf(data interface{}) (Quantity, errors.ICCError) {
+	_, parsed, err := quantity.Parse(data)
+	if err != nil {
+		return Quantity{}, err
+	}
+	return parsed.(Quantity), nil
+}
+
+var quantity = assets.DataType{
+	AcceptedFormats: []string{"@object", "number"},
+	Description:     "A JSON object representing a quantity with fields 'value' and 'unit' (kg, g, L, mL, piece or packet). Bare integers are legacy amounts in the base unit of the ration category.",
+	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
+		var q Quantity
+		switch v := data.(type) {
+		case Quantity:
+			if v.Unit == "" {
+				return legacyQuantity(v.Value)
+			}
+			q = v
+		case float64:
+			return legacyQuantity(v)
+		case int:
+			return legacyQuantity(float64(v))
+		case int64:
+			return legacyQuantity(float64(v))
+		case string:
+			err := json.Unmarshal([]byte(v), &q)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "invalid JSON format", 400)
+			}
+		case map[string]interface{}:
+			quantityJSON, err := json.Marshal(v)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "invalid quantity", 400)
+			}
+			err = json.Unmarshal(quantityJSON, &q)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "invalid quantity", 400)
+			}
+		default:
+			return "", nil, errors.NewCCError("quantity must be an object with a value and a unit", 400)
+		}
+
+		err := q.Unit.CheckType()
+		if err != nil {
+			return "", nil, err
+		}
+
+		if q.Value < 0 {
+			return "", nil, errors.NewCCError("quantity cannot be negative", 400)
+		}
+
+		quantityJSON, nerr := json.Marshal(q)
+		if nerr != nil {
+			return "", nil, errors.WrapErrorWithStatus(nerr, "failed to encode quantity", 500)
+		}
+
+		return string(quantityJSON), q, nil
+	},
+}
+
+// legacyQuantity parses a bare integer amount, as ration quantities were written before units
+// were introduced. It is formatted as the integer datatype was, for the ration keys to match.
+func legacyQuantity(value float64) (string, interface{}, errors.ICCError) {
+	if value < 0 || value != math.Trunc(value) {
+		return "", nil, errors.NewCCError("quantity without unit must be a whole amount of the base unit", 400)
+	}
+	return fmt.Sprintf("%d", int64(value)), Quantity{Value: value}, nil
+}
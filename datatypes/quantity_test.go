@@ -0,0 +1,89 @@
+package datatypes
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConvertTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       Quantity
+		to      Unit
+		want    Quantity
+		wantErr bool
+	}{
+		{"kg to g", Quantity{2.5, UnitKilogram}, UnitGram, Quantity{2500, UnitGram}, false},
+		{"g to kg", Quantity{500, UnitGram}, UnitKilogram, Quantity{0.5, UnitKilogram}, false},
+		{"L to mL", Quantity{1, UnitLitre}, UnitMillilitre, Quantity{1000, UnitMillilitre}, false},
+		{"same unit", Quantity{3, UnitPacket}, UnitPacket, Quantity{3, UnitPacket}, false},
+		{"other dimension", Quantity{1, UnitKilogram}, UnitLitre, Quantity{}, true},
+		{"pieces to packets", Quantity{1, UnitPiece}, UnitPacket, Quantity{}, true},
+		{"unknown source unit", Quantity{1, "lb"}, UnitGram, Quantity{}, true},
+		{"unknown target unit", Quantity{1, UnitGram}, "oz", Quantity{}, true},
+	}
+	for _, tt := range tests {
+		got, err := tt.q.ConvertTo(tt.to)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBaseAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		category RationCategory
+		q        Quantity
+		want     int
+		wantErr  bool
+	}{
+		{"grains in kg", RationCategoryGrains, Quantity{5, UnitKilogram}, 5000, false},
+		{"grains in g", RationCategoryGrains, Quantity{750, UnitGram}, 750, false},
+		{"oil in L", RationCategoryOil, Quantity{1.5, UnitLitre}, 1500, false},
+		{"rounded to the base unit", RationCategorySugar, Quantity{0.0004, UnitKilogram}, 0, false},
+		{"rounded half up", RationCategorySugar, Quantity{1.0005, UnitKilogram}, 1001, false},
+		{"legacy amount without unit", RationCategoryPulses, Quantity{Value: 200}, 200, false},
+		{"oil in kg", RationCategoryOil, Quantity{1, UnitKilogram}, 0, true},
+		{"grains in packets", RationCategoryGrains, Quantity{1, UnitPacket}, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := tt.category.BaseAmount(tt.q)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: got error %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestQuantityLegacyAmount(t *testing.T) {
+	// The key seed must be the one of the integer datatype the quantity prop had
+	seed, parsed, err := quantity.Parse(float64(25))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seed != "25" {
+		t.Errorf("got seed %q, want %q", seed, "25")
+	}
+	written, nerr := json.Marshal(parsed)
+	if nerr != nil {
+		t.Fatal(nerr)
+	}
+	if string(written) != "25" {
+		t.Errorf("legacy amount written back as %s", written)
+	}
+
+	if _, _, err := quantity.Parse(2.5); err == nil {
+		t.Error("fractional amount without unit parsed")
+	}
+	if _, _, err := quantity.Parse(map[string]interface{}{"value": 2.0}); err == nil {
+		t.Error("quantity object without unit parsed")
+	}
+}
@@ -60,3 +60,13 @@ var rationCardCategory = assets.DataType{
 		return fmt.Sprint(retVal), retVal, err
 	},
 }
+
+// Label returns the display name of the card category, as listed in the drop down values
+func (r RationCardCategory) Label() string {
+	for label, value := range rationCardCategory.DropDownValues {
+		if value == r {
+			return label
+		}
+	}
+	return fmt.Sprint(float64(r))
+}
@@ -9,20 +9,35 @@ import (
 )
 
 type RationDistributionHistory struct {
-	DistributionID   string `json:"distributionID"`
-	DistributionDate string `json:"distributionDate"`
-	RationType       string `json:"rationType"`
-	Quantity         int    `json:"quantity"`
-	DistributedTo    string `json:"distributedTo"`
-	Location         string `json:"location"`
+	DistributionID   string    `json:"distributionID"`
+	DistributionDate string    `json:"distributionDate"`
+	RationType       string    `json:"rationType"`
+	RationID         string    `json:"rationID,omitempty"`
+	Quantity         int       `json:"quantity"`
+	Unit             Unit      `json:"unit,omitempty"` // Base unit of the ration category
+	AmountPaid       Money     `json:"amountPaid,omitempty"`
+	SubsidyClaimed   Money     `json:"subsidyClaimed,omitempty"`
+	DistributedTo    string    `json:"distributedTo"` // NID of who collected the ration
+	CollectedBy      Collector `json:"collectedBy,omitempty"`
+	Location         string    `json:"location"`
 }
 
 var rationDistributionHistory = assets.DataType{
 	AcceptedFormats: []string{"@object"},
 	Description:     "A JSON string representing ration distribution history with fields 'distributionID', 'distributionDate', 'rationType', 'quantity', 'distributedTo', and 'location'.",
 	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
-		dataStr, ok := data.(string)
-		if !ok {
+		var dataStr string
+		switch v := data.(type) {
+		case string:
+			dataStr = v
+		case RationDistributionHistory, map[string]interface{}:
+			// Already parsed value, read back from the ledger on updates
+			dataBytes, err := json.Marshal(v)
+			if err != nil {
+				return "", nil, errors.WrapErrorWithStatus(err, "failed to encode distribution history", 400)
+			}
+			dataStr = string(dataBytes)
+		default:
 			return "", nil, errors.NewCCError("property must be a JSON string", 400)
 		}
 
@@ -53,10 +68,21 @@ var rationDistributionHistory = assets.DataType{
 			return "", nil, errors.NewCCError("quantity must be greater than 0", 400)
 		}
 
+		if history.Unit != "" {
+			err := history.Unit.CheckType()
+			if err != nil {
+				return "", nil, err
+			}
+		}
+
 		if history.DistributedTo == "" {
 			return "", nil, errors.NewCCError("distributedTo is required", 400)
 		}
 
+		if history.CollectedBy != "" && history.CollectedBy != CollectorPrincipal && history.CollectedBy != CollectorNominee {
+			return "", nil, errors.NewCCError("invalid collectedBy", 400)
+		}
+
 		if history.Location == "" {
 			return "", nil, errors.NewCCError("location is required", 400)
 		}
@@ -84,6 +84,10 @@ var rationTransaction = assets.DataType{
 				return "", nil, errors.NewCCError("unit is required", 400)
 			}
 
+			if err := Unit(transaction.Unit).CheckType(); err != nil {
+				return "", nil, err
+			}
+
 			if transaction.TransactionDate == "" {
 				return "", nil, errors.NewCCError("transactionDate is required", 400)
 			}
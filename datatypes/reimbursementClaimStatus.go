@@ -0,0 +1,56 @@
+package datatypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+type ReimbursementClaimStatus string
+
+const (
+	ReimbursementClaimStatusRaised            ReimbursementClaimStatus = "raised"
+	ReimbursementClaimStatusVerified          ReimbursementClaimStatus = "verified"
+	ReimbursementClaimStatusApproved          ReimbursementClaimStatus = "approved"
+	ReimbursementClaimStatusPartiallyApproved ReimbursementClaimStatus = "partiallyApproved"
+	ReimbursementClaimStatusRejected          ReimbursementClaimStatus = "rejected"
+	ReimbursementClaimStatusSettled           ReimbursementClaimStatus = "settled"
+)
+
+// Payable tells if a claim in this status is waiting for its payment
+func (s ReimbursementClaimStatus) Payable() bool {
+	return s == ReimbursementClaimStatusApproved || s == ReimbursementClaimStatusPartiallyApproved
+}
+
+var reimbursementClaimStatus = assets.DataType{
+	AcceptedFormats: []string{"string"},
+	DropDownValues: map[string]interface{}{
+		"Raised":             ReimbursementClaimStatusRaised,
+		"Verified":           ReimbursementClaimStatusVerified,
+		"Approved":           ReimbursementClaimStatusApproved,
+		"Partially Approved": ReimbursementClaimStatusPartiallyApproved,
+		"Rejected":           ReimbursementClaimStatusRejected,
+		"Settled":            ReimbursementClaimStatusSettled,
+	},
+	Description: "A string representing the reimbursement claim status.",
+	Parse: func(data interface{}) (string, interface{}, errors.ICCError) {
+		var dataVal string
+		switch v := data.(type) {
+		case string:
+			dataVal = v
+		case ReimbursementClaimStatus:
+			dataVal = string(v)
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+
+		status := ReimbursementClaimStatus(dataVal)
+		switch status {
+		case ReimbursementClaimStatusRaised, ReimbursementClaimStatusVerified, ReimbursementClaimStatusApproved,
+			ReimbursementClaimStatusPartiallyApproved, ReimbursementClaimStatusRejected, ReimbursementClaimStatusSettled:
+			break
+		default:
+			return "", nil, errors.NewCCError("invalid type", 400)
+		}
+		return dataVal, status, nil
+	},
+}
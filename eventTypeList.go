@@ -1,12 +1,14 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/errors"
 	"github.com/hyperledger-labs/cc-tools/events"
 )
 
 var eventTypeList = []events.Event{
-	eventtypes.CreateLibraryLog,
 	eventtypes.RationCardIssuedLog, // Add the new RationCardIssuedLog event
 	eventtypes.MemberInfoUpdatedLog,
 	eventtypes.RationCreatedLog,
@@ -19,4 +21,82 @@ var eventTypeList = []events.Event{
 	eventtypes.PickupScheduleGetLog, // Add the new event
 	eventtypes.RationDeletedLog,
 	eventtypes.RationPurchasedLog,
+	eventtypes.InventoryReplenishedLog,
+	eventtypes.AssetCreatedLog,
+	eventtypes.AssetUpdatedLog,
+	eventtypes.AssetDeletedLog,
+	eventtypes.LowStockAlert,
+	eventtypes.StockThresholdSetLog,
+	eventtypes.PurchaseOrderCreatedLog,
+	eventtypes.PurchaseOrderApprovedLog,
+	eventtypes.DeliveryRecordedLog,
+	eventtypes.ApprovalPolicySetLog,
+	eventtypes.ProposalCreatedLog,
+	eventtypes.ProposalApprovedLog,
+	eventtypes.ProposalsExpiredLog,
+	eventtypes.OperatorAssignedLog,
+	eventtypes.OperatorUnassignedLog,
+	eventtypes.AssetArchivedLog,
+	eventtypes.AssetRestoredLog,
+	eventtypes.MembersRegisteredLog,
+	eventtypes.AdminAreaRegisteredLog,
+	eventtypes.PriceScheduleSetLog,
+	eventtypes.ReimbursementClaimRaisedLog,
+	eventtypes.ReimbursementClaimVerifiedLog,
+	eventtypes.ReimbursementClaimDecidedLog,
+	eventtypes.ReimbursementClaimSettledLog,
+	eventtypes.PickupBookedLog,
+	eventtypes.PickupCodeIssuedLog,
+	eventtypes.PickupCodeCheckedLog,
+	eventtypes.PickupCompletedLog,
+	eventtypes.RestockRequestedLog,
+	eventtypes.IssuerKeyRotatedLog,
+	eventtypes.CardTokenIssuedLog,
+	eventtypes.OfflineDeviceRegisteredLog,
+	eventtypes.OfflineBatchReplayedLog,
+	eventtypes.OfflineExceptionResolvedLog,
+	eventtypes.BiometricBoundLog,
+	eventtypes.BeneficiaryVerifiedLog,
+	eventtypes.NomineeAddedLog,
+	eventtypes.NomineeRemovedLog,
+	eventtypes.PriorityClassSetLog,
+}
+
+// eventStartupCheck verifies that every event emitted by a registered transaction
+// is registered in eventTypeList and has a versioned payload.
+func eventStartupCheck() errors.ICCError {
+	for _, event := range eventTypeList {
+		if _, ok := eventtypes.PayloadVersions[event.Tag]; !ok {
+			return errors.NewCCError(fmt.Sprintf("event %s has no payload version", event.Tag), 500)
+		}
+	}
+
+	emitted := map[string]bool{}
+	registered := map[string]bool{}
+	for _, t := range txList {
+		registered[t.Tag] = true
+		eventTags, ok := txEventList[t.Tag]
+		if !ok {
+			return errors.NewCCError(fmt.Sprintf("tx %s is missing from txEventList", t.Tag), 500)
+		}
+		for _, eventTag := range eventTags {
+			if events.FetchEvent(eventTag) == nil {
+				return errors.NewCCError(fmt.Sprintf("tx %s emits unregistered event %s", t.Tag, eventTag), 500)
+			}
+			emitted[eventTag] = true
+		}
+	}
+
+	for txTag := range txEventList {
+		if !registered[txTag] {
+			return errors.NewCCError(fmt.Sprintf("txEventList declares unregistered tx %s", txTag), 500)
+		}
+	}
+	for _, event := range eventTypeList {
+		if !emitted[event.Tag] {
+			return errors.NewCCError(fmt.Sprintf("event %s is emitted by no transaction", event.Tag), 500)
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,21 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var AdminAreaRegisteredLog = events.Event{
+	Tag:         "adminAreaRegisteredLog",
+	Label:       "Administrative Area Registered Log",
+	Description: "Log of an administrative area added to the reference table",
+	Type:        events.EventLog,
+	BaseLog:     "Administrative area registered",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$org3MSP", "$orgMSP"},
+}
+
+// AdminAreaRegisteredPayload is the payload emitted with adminAreaRegisteredLog
+type AdminAreaRegisteredPayload struct {
+	EventPayload
+	Path  string `json:"path"`
+	Level string `json:"level"`
+}
@@ -0,0 +1,24 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var ApprovalPolicySetLog = events.Event{
+	Tag:         "approvalPolicySetLog",
+	Label:       "Approval Policy Set Log",
+	Description: "Log of a multi-signature policy change",
+	Type:        events.EventLog,
+	BaseLog:     "Approval policy set",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$org3MSP", "$orgMSP"},
+}
+
+// ApprovalPolicySetPayload is the payload emitted with approvalPolicySetLog
+type ApprovalPolicySetPayload struct {
+	EventPayload
+	TxTag         string   `json:"txTag"`
+	Approvers     []string `json:"approvers"`
+	Threshold     int      `json:"threshold"`
+	ValidityHours int      `json:"validityHours"`
+	MinimumSize   int      `json:"minimumSize,omitempty"`
+}
@@ -0,0 +1,22 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+// AssetArchivedLog is emitted when a member or a ration is archived
+var AssetArchivedLog = events.Event{
+	Tag:         "assetArchivedLog",
+	Label:       "Asset Archived Log",
+	Description: "Log of a member or ration archived",
+	Type:        events.EventLog,
+	BaseLog:     "Asset archived",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$org3MSP", "$orgMSP"},
+}
+
+// AssetArchivedPayload is the payload emitted with assetArchivedLog
+type AssetArchivedPayload struct {
+	EventPayload
+	Assets []AssetRef `json:"assets"`
+	Reason string     `json:"reason,omitempty"`
+}
@@ -0,0 +1,21 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+// AssetCreatedLog is emitted when a domain asset is created through the generic createAsset transaction
+var AssetCreatedLog = events.Event{
+	Tag:         "assetCreatedLog",
+	Label:       "Asset Created Log",
+	Description: "Log of a domain asset created through the generic asset transactions",
+	Type:        events.EventLog,
+	BaseLog:     "Asset created",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$org3MSP", "$orgMSP"},
+}
+
+// AssetCreatedPayload is the payload emitted with assetCreatedLog
+type AssetCreatedPayload struct {
+	EventPayload
+	Assets []AssetRef `json:"assets"`
+}
@@ -0,0 +1,21 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+// AssetDeletedLog is emitted when a domain asset is deleted through the generic deleteAsset transaction
+var AssetDeletedLog = events.Event{
+	Tag:         "assetDeletedLog",
+	Label:       "Asset Deleted Log",
+	Description: "Log of a domain asset deleted through the generic asset transactions",
+	Type:        events.EventLog,
+	BaseLog:     "Asset deleted",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$org3MSP", "$orgMSP"},
+}
+
+// AssetDeletedPayload is the payload emitted with assetDeletedLog
+type AssetDeletedPayload struct {
+	EventPayload
+	Assets []AssetRef `json:"assets"`
+}
@@ -0,0 +1,22 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+// AssetRestoredLog is emitted when a member or a ration is restored
+var AssetRestoredLog = events.Event{
+	Tag:         "assetRestoredLog",
+	Label:       "Asset Restored Log",
+	Description: "Log of a member or ration restored",
+	Type:        events.EventLog,
+	BaseLog:     "Asset restored",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$org3MSP", "$orgMSP"},
+}
+
+// AssetRestoredPayload is the payload emitted with assetRestoredLog
+type AssetRestoredPayload struct {
+	EventPayload
+	Assets []AssetRef `json:"assets"`
+	Reason string     `json:"reason,omitempty"`
+}
@@ -0,0 +1,22 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+// AssetUpdatedLog is emitted when a domain asset is updated through the generic updateAsset transaction
+var AssetUpdatedLog = events.Event{
+	Tag:         "assetUpdatedLog",
+	Label:       "Asset Updated Log",
+	Description: "Log of a domain asset updated through the generic asset transactions",
+	Type:        events.EventLog,
+	BaseLog:     "Asset updated",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$org3MSP", "$orgMSP"},
+}
+
+// AssetUpdatedPayload is the payload emitted with assetUpdatedLog
+type AssetUpdatedPayload struct {
+	EventPayload
+	Assets        []AssetRef `json:"assets"`
+	UpdatedFields []string   `json:"updatedFields"`
+}
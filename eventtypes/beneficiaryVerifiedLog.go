@@ -0,0 +1,24 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var BeneficiaryVerifiedLog = events.Event{
+	Tag:         "beneficiaryVerifiedLog",
+	Label:       "Beneficiary Verified Log",
+	Description: "Log of a fingerprint checked against the one bound to a member",
+	Type:        events.EventLog,
+	BaseLog:     "Beneficiary verified",
+	Receivers:   []string{"$org1MSP", "$orgMSP"},
+}
+
+// BeneficiaryVerifiedPayload is the payload emitted with beneficiaryVerifiedLog
+type BeneficiaryVerifiedPayload struct {
+	EventPayload
+	RationCardNumber    string `json:"rationCardNumber"`
+	DistributionPointID string `json:"distributionPointId"`
+	Match               bool   `json:"match"`
+	AttemptsLeft        int    `json:"attemptsLeft"`
+	Locked              bool   `json:"locked"`
+}
@@ -0,0 +1,21 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var BiometricBoundLog = events.Event{
+	Tag:         "biometricBoundLog",
+	Label:       "Biometric Bound Log",
+	Description: "Log of a fingerprint bound to a member",
+	Type:        events.EventLog,
+	BaseLog:     "Biometric bound",
+	Receivers:   []string{"$org1MSP", "$orgMSP"},
+}
+
+// BiometricBoundPayload is the payload emitted with biometricBoundLog
+type BiometricBoundPayload struct {
+	EventPayload
+	NID     string `json:"nid"`
+	Rebound bool   `json:"rebound"`
+}
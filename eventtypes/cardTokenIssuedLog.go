@@ -0,0 +1,23 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var CardTokenIssuedLog = events.Event{
+	Tag:         "cardTokenIssuedLog",
+	Label:       "Card Token Issued Log",
+	Description: "Log of a signed ration card token recorded for offline verification",
+	Type:        events.EventLog,
+	BaseLog:     "Card token issued",
+	Receivers:   []string{"$org1MSP", "$org3MSP", "$orgMSP"},
+}
+
+// CardTokenIssuedPayload is the payload emitted with cardTokenIssuedLog
+type CardTokenIssuedPayload struct {
+	EventPayload
+	RationCardNumber string `json:"rationCardNumber"`
+	KeyID            string `json:"keyId"`
+	TokenHash        string `json:"tokenHash"`
+	Expiry           string `json:"expiry"`
+}
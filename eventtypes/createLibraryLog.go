@@ -11,3 +11,9 @@ var CreateLibraryLog = events.Event{
 	BaseLog:     "New library created",           // BaseLog is a base message to be logged
 	Receivers:   []string{"$org2MSP", "$orgMSP"}, // Receivers are the MSPs that will receive the event
 }
+
+// LibraryCreatedPayload is the payload emitted with createLibraryLog
+type LibraryCreatedPayload struct {
+	EventPayload
+	Name string `json:"name"`
+}
@@ -0,0 +1,25 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var DeliveryRecordedLog = events.Event{
+	Tag:         "deliveryRecordedLog",
+	Label:       "Delivery Recorded Log",
+	Description: "Log of ration batches delivered against a purchase order",
+	Type:        events.EventLog,
+	BaseLog:     "Delivery recorded",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$orgMSP"},
+}
+
+// DeliveryRecordedPayload is the payload emitted with deliveryRecordedLog
+type DeliveryRecordedPayload struct {
+	EventPayload
+	DeliveryID  string                        `json:"deliveryId"`
+	OrderID     string                        `json:"orderId"`
+	RationIDs   []string                      `json:"rationIds"`
+	LineItems   []datatypes.PurchaseOrderLine `json:"lineItems"`
+	OrderStatus datatypes.PurchaseOrderStatus `json:"orderStatus"`
+}
@@ -12,3 +12,10 @@ var DistributionPointCreatedLog = events.Event{
 	BaseLog:     "New distribution point created",
 	Receivers:   []string{"$org1MSP", "$orgMSP"},
 }
+
+// DistributionPointCreatedPayload is the payload emitted with distributionPointCreatedLog
+type DistributionPointCreatedPayload struct {
+	EventPayload
+	DistributionPointID string `json:"distributionPointId"`
+	Capacity            int    `json:"capacity"`
+}
@@ -12,3 +12,10 @@ var DistributorCreatedLog = events.Event{
 	BaseLog:     "New distributor created",
 	Receivers:   []string{"$org1MSP", "$orgMSP"},
 }
+
+// DistributorCreatedPayload is the payload emitted with distributorCreatedLog
+type DistributorCreatedPayload struct {
+	EventPayload
+	DistributorID    string `json:"distributorId"`
+	DistributionArea string `json:"distributionArea"`
+}
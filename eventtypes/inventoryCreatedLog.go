@@ -12,3 +12,10 @@ var InventoryCreatedLog = events.Event{
 	BaseLog:     "New inventory created",
 	Receivers:   []string{"$org3MSP", "$orgMSP"},
 }
+
+// InventoryCreatedPayload is the payload emitted with inventoryCreatedLog
+type InventoryCreatedPayload struct {
+	EventPayload
+	InventoryName string `json:"inventoryName"`
+	RationCount   int    `json:"rationCount"`
+}
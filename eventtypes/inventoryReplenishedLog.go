@@ -0,0 +1,21 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var InventoryReplenishedLog = events.Event{
+	Tag:         "inventoryReplenishedLog",
+	Label:       "Inventory Replenished Log",
+	Description: "Log of a distribution point inventory replenishment",
+	Type:        events.EventLog,
+	BaseLog:     "Inventory replenished",
+	Receivers:   []string{"$org1MSP", "$orgMSP"},
+}
+
+// InventoryReplenishedPayload is the payload emitted with inventoryReplenishedLog
+type InventoryReplenishedPayload struct {
+	EventPayload
+	DistributionPointID string   `json:"distributionPointId"`
+	RationIDs           []string `json:"rationIds"`
+}
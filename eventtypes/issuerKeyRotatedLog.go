@@ -0,0 +1,23 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var IssuerKeyRotatedLog = events.Event{
+	Tag:         "issuerKeyRotatedLog",
+	Label:       "Issuer Key Rotated Log",
+	Description: "Log of a new key an org signs ration card tokens with",
+	Type:        events.EventLog,
+	BaseLog:     "Issuer key rotated",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$org3MSP", "$orgMSP"},
+}
+
+// IssuerKeyRotatedPayload is the payload emitted with issuerKeyRotatedLog
+type IssuerKeyRotatedPayload struct {
+	EventPayload
+	Issuer      string   `json:"issuer"`
+	KeyID       string   `json:"keyId"`
+	PublicKey   string   `json:"publicKey"`
+	RetiredKeys []string `json:"retiredKeys"`
+}
@@ -0,0 +1,39 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+// ProcurementChannel is the channel where the ministry gathers restock requests
+// coming from the distribution point channels
+const ProcurementChannel = "procurement"
+
+// LowStockAlert carries a low stock alert raised by raiseLowStockAlert from a distribution point
+// channel to the procurement channel, where the CCAPI calls receiveLowStockAlert with the
+// payload as request. Fabric keeps a single event per transaction, so the stock-decreasing
+// transactions, which log their own event, record their alerts as restockRequests in the same
+// transaction instead, and only copy them in their event (the lowStockAlert field of
+// rationPurchasedLog and stockThresholdSetLog, lowStockAlerts of offlineBatchReplayedLog).
+var LowStockAlert = events.Event{
+	Tag:         "lowStockAlert",
+	Label:       "Low Stock Alert",
+	Description: "Alert of a distribution point running low on a ration category",
+	Type:        events.EventTransaction,
+	BaseLog:     "Low stock alert",
+	Receivers:   []string{"$org2MSP", "$orgMSP"},
+	Transaction: "receiveLowStockAlert",
+	Channel:     ProcurementChannel,
+}
+
+// LowStockAlertPayload is the payload emitted with lowStockAlert.
+// Its fields are the arguments of the receiveLowStockAlert transaction.
+type LowStockAlertPayload struct {
+	EventPayload
+	SourceChannel       string                   `json:"sourceChannel"`
+	DistributionPointID string                   `json:"distributionPointId"`
+	Category            datatypes.RationCategory `json:"category"`
+	CurrentLevel        int                      `json:"currentLevel"`
+	SuggestedQuantity   int                      `json:"suggestedQuantity"`
+	Unit                datatypes.Unit           `json:"unit"` // Base unit of the ration category
+}
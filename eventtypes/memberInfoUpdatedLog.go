@@ -12,3 +12,12 @@ var MemberInfoUpdatedLog = events.Event{
 	BaseLog:     "Member information updated",
 	Receivers:   []string{"$org1MSP", "$orgMSP"},
 }
+
+// MemberInfoUpdatedPayload is the payload emitted with memberInfoUpdatedLog
+type MemberInfoUpdatedPayload struct {
+	EventPayload
+	NID           string   `json:"nid"`
+	UpdatedFields []string `json:"updatedFields"`
+	StatusBefore  string   `json:"statusBefore"`
+	StatusAfter   string   `json:"statusAfter"`
+}
@@ -0,0 +1,23 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var MembersRegisteredLog = events.Event{
+	Tag:         "membersRegisteredLog",
+	Label:       "Members Registered Log",
+	Description: "Log of a batch of members registered at once",
+	Type:        events.EventLog,
+	BaseLog:     "Members registered",
+	Receivers:   []string{"$org1MSP", "$org3MSP", "$orgMSP"},
+}
+
+// MembersRegisteredPayload is the payload emitted with membersRegisteredLog
+type MembersRegisteredPayload struct {
+	EventPayload
+	Created    int        `json:"created"`
+	Duplicates int        `json:"duplicates"`
+	Invalid    int        `json:"invalid"`
+	Members    []AssetRef `json:"members"`
+}
@@ -0,0 +1,23 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var NomineeAddedLog = events.Event{
+	Tag:         "nomineeAddedLog",
+	Label:       "Nominee Added Log",
+	Description: "Log of a nominee authorized to collect the rations of a member",
+	Type:        events.EventLog,
+	BaseLog:     "Nominee added",
+	Receivers:   []string{"$org1MSP", "$orgMSP"},
+}
+
+// NomineeAddedPayload is the payload emitted with nomineeAddedLog
+type NomineeAddedPayload struct {
+	EventPayload
+	PrincipalNID string `json:"principalNid"`
+	NomineeNID   string `json:"nomineeNid"`
+	ValidFrom    string `json:"validFrom"`
+	ValidUntil   string `json:"validUntil"`
+}
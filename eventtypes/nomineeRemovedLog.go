@@ -0,0 +1,22 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var NomineeRemovedLog = events.Event{
+	Tag:         "nomineeRemovedLog",
+	Label:       "Nominee Removed Log",
+	Description: "Log of a nominee no longer authorized to collect the rations of a member",
+	Type:        events.EventLog,
+	BaseLog:     "Nominee removed",
+	Receivers:   []string{"$org1MSP", "$orgMSP"},
+}
+
+// NomineeRemovedPayload is the payload emitted with nomineeRemovedLog
+type NomineeRemovedPayload struct {
+	EventPayload
+	PrincipalNID string `json:"principalNid"`
+	NomineeNID   string `json:"nomineeNid"`
+	Reason       string `json:"reason,omitempty"`
+}
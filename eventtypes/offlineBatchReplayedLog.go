@@ -0,0 +1,30 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var OfflineBatchReplayedLog = events.Event{
+	Tag:         "offlineBatchReplayedLog",
+	Label:       "Offline Batch Replayed Log",
+	Description: "Log of a batch of offline distributions replayed on the ledger",
+	Type:        events.EventLog,
+	BaseLog:     "Offline batch replayed",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$orgMSP"},
+}
+
+// OfflineBatchReplayedPayload is the payload emitted with offlineBatchReplayedLog
+type OfflineBatchReplayedPayload struct {
+	EventPayload
+	BatchID             string `json:"batchId"`
+	DeviceID            string `json:"deviceId"`
+	DistributionPointID string `json:"distributionPointId"`
+	FirstSequence       int    `json:"firstSequence"`
+	LastSequence        int    `json:"lastSequence"`
+	Recorded            int    `json:"recorded"`
+	Exceptions          int    `json:"exceptions"`
+	MissingSequences    int    `json:"missingSequences"`
+
+	// Alerts of the stocks the batch made cross their minimum level
+	LowStockAlerts []LowStockAlertPayload `json:"lowStockAlerts,omitempty"`
+}
@@ -0,0 +1,21 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var OfflineDeviceRegisteredLog = events.Event{
+	Tag:         "offlineDeviceRegisteredLog",
+	Label:       "Offline Device Registered Log",
+	Description: "Log of a device registered to record distributions offline",
+	Type:        events.EventLog,
+	BaseLog:     "Offline device registered",
+	Receivers:   []string{"$org1MSP", "$orgMSP"},
+}
+
+// OfflineDeviceRegisteredPayload is the payload emitted with offlineDeviceRegisteredLog
+type OfflineDeviceRegisteredPayload struct {
+	EventPayload
+	DeviceID            string `json:"deviceId"`
+	DistributionPointID string `json:"distributionPointId"`
+}
@@ -0,0 +1,24 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var OfflineExceptionResolvedLog = events.Event{
+	Tag:         "offlineExceptionResolvedLog",
+	Label:       "Offline Exception Resolved Log",
+	Description: "Log of the review of an offline distribution conflicting with the ledger",
+	Type:        events.EventLog,
+	BaseLog:     "Offline exception resolved",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$orgMSP"},
+}
+
+// OfflineExceptionResolvedPayload is the payload emitted with offlineExceptionResolvedLog
+type OfflineExceptionResolvedPayload struct {
+	EventPayload
+	ExceptionID string                           `json:"exceptionId"`
+	Reason      datatypes.OfflineExceptionReason `json:"reason"`
+	Resolution  string                           `json:"resolution"`
+	ResolvedBy  string                           `json:"resolvedBy"`
+}
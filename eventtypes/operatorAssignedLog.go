@@ -0,0 +1,22 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var OperatorAssignedLog = events.Event{
+	Tag:         "operatorAssignedLog",
+	Label:       "Operator Assigned Log",
+	Description: "Log of an operator assigned to a distribution point",
+	Type:        events.EventLog,
+	BaseLog:     "Operator assigned",
+	Receivers:   []string{"$org1MSP", "$orgMSP"},
+}
+
+// OperatorAssignedPayload is the payload emitted with operatorAssignedLog
+type OperatorAssignedPayload struct {
+	EventPayload
+	OperatorMSP         string `json:"operatorMsp"`
+	OperatorID          string `json:"operatorId"`
+	DistributionPointID string `json:"distributionPointId"`
+}
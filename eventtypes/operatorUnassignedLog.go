@@ -0,0 +1,22 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var OperatorUnassignedLog = events.Event{
+	Tag:         "operatorUnassignedLog",
+	Label:       "Operator Unassigned Log",
+	Description: "Log of an operator unassigned from a distribution point",
+	Type:        events.EventLog,
+	BaseLog:     "Operator unassigned",
+	Receivers:   []string{"$org1MSP", "$orgMSP"},
+}
+
+// OperatorUnassignedPayload is the payload emitted with operatorUnassignedLog
+type OperatorUnassignedPayload struct {
+	EventPayload
+	OperatorMSP         string `json:"operatorMsp"`
+	OperatorID          string `json:"operatorId"`
+	DistributionPointID string `json:"distributionPointId"`
+}
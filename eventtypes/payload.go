@@ -0,0 +1,103 @@
+package eventtypes
+
+import (
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+)
+
+// PayloadVersions holds the schema version of the payload emitted by each event.
+// Bump the version of an event whenever a field of its payload is renamed or removed.
+var PayloadVersions = map[string]int{
+	"rationCardIssuedLog":           1,
+	"memberInfoUpdatedLog":          1,
+	"rationCreatedLog":              1,
+	"rationUpdatedLog":              1,
+	"distributorCreatedLog":         1,
+	"distributionPointCreatedLog":   1,
+	"inventoryCreatedLog":           1,
+	"pickupScheduleSetLog":          1,
+	"pickupScheduleGetLog":          1,
+	"rationDeletedLog":              1,
+	"rationPurchasedLog":            2, // quantity is in the base unit of the category
+	"inventoryReplenishedLog":       1,
+	"assetCreatedLog":               1,
+	"assetUpdatedLog":               1,
+	"assetDeletedLog":               1,
+	"lowStockAlert":                 1,
+	"purchaseOrderCreatedLog":       1,
+	"purchaseOrderApprovedLog":      1,
+	"deliveryRecordedLog":           1,
+	"approvalPolicySetLog":          1,
+	"proposalCreatedLog":            1,
+	"proposalApprovedLog":           1,
+	"proposalsExpiredLog":           1,
+	"operatorAssignedLog":           1,
+	"operatorUnassignedLog":         1,
+	"assetArchivedLog":              1,
+	"assetRestoredLog":              1,
+	"membersRegisteredLog":          1,
+	"adminAreaRegisteredLog":        1,
+	"priceScheduleSetLog":           1,
+	"reimbursementClaimRaisedLog":   1,
+	"reimbursementClaimVerifiedLog": 1,
+	"reimbursementClaimDecidedLog":  1,
+	"reimbursementClaimSettledLog":  1,
+	"pickupBookedLog":               1,
+	"pickupCodeIssuedLog":           1,
+	"pickupCodeCheckedLog":          1,
+	"pickupCompletedLog":            1,
+	"restockRequestedLog":           1,
+	"issuerKeyRotatedLog":           1,
+	"cardTokenIssuedLog":            1,
+	"offlineDeviceRegisteredLog":    1,
+	"offlineBatchReplayedLog":       1,
+	"offlineExceptionResolvedLog":   1,
+	"biometricBoundLog":             1,
+	"beneficiaryVerifiedLog":        1,
+	"nomineeAddedLog":               1,
+	"nomineeRemovedLog":             1,
+	"priorityClassSetLog":           1,
+	"stockThresholdSetLog":          1,
+}
+
+// EventPayload is the envelope shared by every event payload
+type EventPayload struct {
+	Event     string `json:"event"`
+	Version   int    `json:"version"`
+	AssetKey  string `json:"assetKey,omitempty"`
+	ActorMSP  string `json:"actorMSP"`
+	TxID      string `json:"txId"`
+	Timestamp string `json:"timestamp"`
+	Message   string `json:"message"` // Human-readable description of the event
+}
+
+// AssetRef identifies an asset touched by a transaction
+type AssetRef struct {
+	AssetType string `json:"assetType"`
+	Key       string `json:"key"`
+}
+
+// NewEventPayload fills the envelope of an event with the data of the current transaction
+func NewEventPayload(stub *sw.StubWrapper, eventTag, assetKey, message string) (EventPayload, errors.ICCError) {
+	actorMSP, err := stub.GetMSPID()
+	if err != nil {
+		return EventPayload{}, errors.WrapError(err, "failed to get caller MSP")
+	}
+
+	txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+	if nerr != nil {
+		return EventPayload{}, errors.WrapError(nerr, "failed to get transaction timestamp")
+	}
+
+	return EventPayload{
+		Event:     eventTag,
+		Version:   PayloadVersions[eventTag],
+		AssetKey:  assetKey,
+		ActorMSP:  actorMSP,
+		TxID:      stub.Stub.GetTxID(),
+		Timestamp: txTimestamp.AsTime().Format(time.RFC3339),
+		Message:   message,
+	}, nil
+}
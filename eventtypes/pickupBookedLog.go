@@ -0,0 +1,26 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var PickupBookedLog = events.Event{
+	Tag:         "pickupBookedLog",
+	Label:       "Pickup Booked Log",
+	Description: "Log of a pickup booked by a member at a distribution point",
+	Type:        events.EventLog,
+	BaseLog:     "Pickup booked",
+	Receivers:   []string{"$org1MSP", "$orgMSP"},
+}
+
+// PickupBookedPayload is the payload emitted with pickupBookedLog
+type PickupBookedPayload struct {
+	EventPayload
+	BookingID           string `json:"bookingId"`
+	RationCardNumber    string `json:"rationCardNumber"`
+	DistributionPointID string `json:"distributionPointId"`
+	PickupDate          string `json:"pickupDate"`
+	ExpiryDate          string `json:"expiryDate"`
+	Priority            bool   `json:"priority"`
+	HomeDelivery        bool   `json:"homeDelivery"`
+}
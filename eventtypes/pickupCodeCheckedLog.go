@@ -0,0 +1,25 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var PickupCodeCheckedLog = events.Event{
+	Tag:         "pickupCodeCheckedLog",
+	Label:       "Pickup Code Checked Log",
+	Description: "Log of a one-time pickup code submitted by an operator",
+	Type:        events.EventLog,
+	BaseLog:     "Pickup code checked",
+	Receivers:   []string{"$org1MSP", "$orgMSP"},
+}
+
+// PickupCodeCheckedPayload is the payload emitted with pickupCodeCheckedLog
+type PickupCodeCheckedPayload struct {
+	EventPayload
+	BookingID           string                        `json:"bookingId"`
+	DistributionPointID string                        `json:"distributionPointId"`
+	Match               bool                          `json:"match"`
+	AttemptsLeft        int                           `json:"attemptsLeft"`
+	Status              datatypes.PickupBookingStatus `json:"status"`
+}
@@ -0,0 +1,22 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var PickupCodeIssuedLog = events.Event{
+	Tag:         "pickupCodeIssuedLog",
+	Label:       "Pickup Code Issued Log",
+	Description: "Log of a one-time pickup code issued to a member for a booking",
+	Type:        events.EventLog,
+	BaseLog:     "Pickup code issued",
+	Receivers:   []string{"$org1MSP", "$orgMSP"},
+}
+
+// PickupCodeIssuedPayload is the payload emitted with pickupCodeIssuedLog
+type PickupCodeIssuedPayload struct {
+	EventPayload
+	BookingID           string `json:"bookingId"`
+	DistributionPointID string `json:"distributionPointId"`
+	ExpiryDate          string `json:"expiryDate"`
+}
@@ -0,0 +1,22 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var PickupCompletedLog = events.Event{
+	Tag:         "pickupCompletedLog",
+	Label:       "Pickup Completed Log",
+	Description: "Log of the end of a visit of a member collecting rations with a booking",
+	Type:        events.EventLog,
+	BaseLog:     "Pickup completed",
+	Receivers:   []string{"$org1MSP", "$orgMSP"},
+}
+
+// PickupCompletedPayload is the payload emitted with pickupCompletedLog
+type PickupCompletedPayload struct {
+	EventPayload
+	BookingID           string `json:"bookingId"`
+	DistributionPointID string `json:"distributionPointId"`
+	Sales               int    `json:"sales"`
+}
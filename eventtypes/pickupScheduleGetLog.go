@@ -12,3 +12,9 @@ var PickupScheduleGetLog = events.Event{
 	BaseLog:     "Pickup schedule retrieved",
 	Receivers:   []string{"$org1MSP", "$orgMSP"},
 }
+
+// PickupScheduleGetPayload is the payload emitted with pickupScheduleGetLog
+type PickupScheduleGetPayload struct {
+	EventPayload
+	DistributionPointID string `json:"distributionPointId"`
+}
@@ -12,3 +12,12 @@ var PickupScheduleSetLog = events.Event{
 	BaseLog:     "Pickup schedule set",
 	Receivers:   []string{"$org1MSP", "$orgMSP"},
 }
+
+// PickupScheduleSetPayload is the payload emitted with pickupScheduleSetLog
+type PickupScheduleSetPayload struct {
+	EventPayload
+	DistributionPointID string `json:"distributionPointId"`
+	PickupDate          string `json:"pickupDate"`
+	RationType          string `json:"rationType"`
+	Quantity            int    `json:"quantity"`
+}
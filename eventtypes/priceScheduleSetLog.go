@@ -0,0 +1,28 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var PriceScheduleSetLog = events.Event{
+	Tag:         "priceScheduleSetLog",
+	Label:       "Price Schedule Set Log",
+	Description: "Log of a ration price set for a ration card category",
+	Type:        events.EventLog,
+	BaseLog:     "Price schedule set",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$orgMSP"},
+}
+
+// PriceScheduleSetPayload is the payload emitted with priceScheduleSetLog
+type PriceScheduleSetPayload struct {
+	EventPayload
+	Category        string          `json:"category"`
+	CardCategory    string          `json:"cardCategory"`
+	StartDate       string          `json:"startDate"`
+	EndDate         string          `json:"endDate,omitempty"`
+	Unit            datatypes.Unit  `json:"unit"`
+	MarketPrice     datatypes.Money `json:"marketPrice"`
+	SubsidizedPrice datatypes.Money `json:"subsidizedPrice"`
+	SubsidyShare    int             `json:"subsidyShare"` // Percentage of the subsidy reimbursed by the government
+}
@@ -0,0 +1,26 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var PriorityClassSetLog = events.Event{
+	Tag:         "priorityClassSetLog",
+	Label:       "Priority Class Set Log",
+	Description: "Log of the priority service given to a class of members",
+	Type:        events.EventLog,
+	BaseLog:     "Priority class set",
+	Receivers:   []string{"$org1MSP", "$orgMSP"},
+}
+
+// PriorityClassSetPayload is the payload emitted with priorityClassSetLog
+type PriorityClassSetPayload struct {
+	EventPayload
+	Criterion         datatypes.PriorityCriterion `json:"criterion"`
+	MinimumAge        int                         `json:"minimumAge,omitempty"`
+	ReservedSlotShare int                         `json:"reservedSlotShare"` // Percentage of the daily pickup slots
+	EntitlementBonus  int                         `json:"entitlementBonus"`  // Percentage added to the ration quantity
+	HomeDelivery      bool                        `json:"homeDelivery"`
+	Active            bool                        `json:"active"`
+}
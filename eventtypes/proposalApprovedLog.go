@@ -0,0 +1,23 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var ProposalApprovedLog = events.Event{
+	Tag:         "proposalApprovedLog",
+	Label:       "Proposal Approved Log",
+	Description: "Log of an approval of a proposal",
+	Type:        events.EventLog,
+	BaseLog:     "Proposal approved",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$org3MSP", "$orgMSP"},
+}
+
+// ProposalApprovedPayload is the payload emitted with proposalApprovedLog
+type ProposalApprovedPayload struct {
+	EventPayload
+	ProposalID string   `json:"proposalId"`
+	TxTag      string   `json:"txTag"`
+	Approvals  []string `json:"approvals"`
+	Threshold  int      `json:"threshold"`
+}
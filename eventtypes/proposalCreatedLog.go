@@ -0,0 +1,23 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var ProposalCreatedLog = events.Event{
+	Tag:         "proposalCreatedLog",
+	Label:       "Proposal Created Log",
+	Description: "Log of a transaction proposed for multi-signature approval",
+	Type:        events.EventLog,
+	BaseLog:     "New proposal created",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$org3MSP", "$orgMSP"},
+}
+
+// ProposalCreatedPayload is the payload emitted with proposalCreatedLog
+type ProposalCreatedPayload struct {
+	EventPayload
+	ProposalID string `json:"proposalId"`
+	TxTag      string `json:"txTag"`
+	ProposedBy string `json:"proposedBy"`
+	ExpiryDate string `json:"expiryDate"`
+}
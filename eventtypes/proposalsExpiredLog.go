@@ -0,0 +1,20 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var ProposalsExpiredLog = events.Event{
+	Tag:         "proposalsExpiredLog",
+	Label:       "Proposals Expired Log",
+	Description: "Log of stale proposals expiring",
+	Type:        events.EventLog,
+	BaseLog:     "Proposals expired",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$org3MSP", "$orgMSP"},
+}
+
+// ProposalsExpiredPayload is the payload emitted with proposalsExpiredLog
+type ProposalsExpiredPayload struct {
+	EventPayload
+	ProposalIDs []string `json:"proposalIds"`
+}
@@ -0,0 +1,21 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var PurchaseOrderApprovedLog = events.Event{
+	Tag:         "purchaseOrderApprovedLog",
+	Label:       "Purchase Order Approved Log",
+	Description: "Log of purchase order approval",
+	Type:        events.EventLog,
+	BaseLog:     "Purchase order approved",
+	Receivers:   []string{"$org2MSP", "$orgMSP"},
+}
+
+// PurchaseOrderApprovedPayload is the payload emitted with purchaseOrderApprovedLog
+type PurchaseOrderApprovedPayload struct {
+	EventPayload
+	OrderID    string `json:"orderId"`
+	ApprovedBy string `json:"approvedBy"`
+}
@@ -0,0 +1,23 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var PurchaseOrderCreatedLog = events.Event{
+	Tag:         "purchaseOrderCreatedLog",
+	Label:       "Purchase Order Created Log",
+	Description: "Log of purchase order creation",
+	Type:        events.EventLog,
+	BaseLog:     "New purchase order created",
+	Receivers:   []string{"$org2MSP", "$orgMSP"},
+}
+
+// PurchaseOrderCreatedPayload is the payload emitted with purchaseOrderCreatedLog
+type PurchaseOrderCreatedPayload struct {
+	EventPayload
+	OrderID       string                        `json:"orderId"`
+	DistributorID string                        `json:"distributorId"`
+	LineItems     []datatypes.PurchaseOrderLine `json:"lineItems"`
+}
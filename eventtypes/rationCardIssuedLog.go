@@ -12,3 +12,13 @@ var RationCardIssuedLog = events.Event{
 	BaseLog:     "New ration card issued",
 	Receivers:   []string{"$org1MSP", "$orgMSP"},
 }
+
+// RationCardIssuedPayload is the payload emitted with rationCardIssuedLog
+type RationCardIssuedPayload struct {
+	EventPayload
+	NID                string `json:"nid"`
+	RationCardNumber   string `json:"rationCardNumber"`
+	RationCardCategory string `json:"rationCardCategory"`
+	StatusBefore       string `json:"statusBefore"`
+	StatusAfter        string `json:"statusAfter"`
+}
@@ -1,6 +1,7 @@
 package eventtypes
 
 import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
 	"github.com/hyperledger-labs/cc-tools/events"
 )
 
@@ -12,3 +13,13 @@ var RationCreatedLog = events.Event{
 	BaseLog:     "New ration created",
 	Receivers:   []string{"$org2MSP", "$orgMSP"},
 }
+
+// RationCreatedPayload is the payload emitted with rationCreatedLog
+type RationCreatedPayload struct {
+	EventPayload
+	RationID    string         `json:"rationId"`
+	Category    string         `json:"category"`
+	Quantity    int            `json:"quantity"`
+	Unit        datatypes.Unit `json:"unit"` // Base unit of the ration category
+	BatchNumber int            `json:"batchNumber"`
+}
@@ -1,6 +1,7 @@
 package eventtypes
 
 import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
 	"github.com/hyperledger-labs/cc-tools/events"
 )
 
@@ -12,3 +13,11 @@ var RationDeletedLog = events.Event{
 	BaseLog:     "Ration deleted",
 	Receivers:   []string{"$org2MSP", "$orgMSP"},
 }
+
+// RationDeletedPayload is the payload emitted with rationDeletedLog
+type RationDeletedPayload struct {
+	EventPayload
+	RationID string         `json:"rationId"`
+	Quantity int            `json:"quantity"`
+	Unit     datatypes.Unit `json:"unit"` // Base unit of the ration category
+}
@@ -1,6 +1,7 @@
 package eventtypes
 
 import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
 	"github.com/hyperledger-labs/cc-tools/events"
 )
 
@@ -10,5 +11,30 @@ var RationPurchasedLog = events.Event{
 	Description: "Log of ration purchase",
 	Type:        events.EventLog,
 	BaseLog:     "Ration purchased",
-	Receivers:   []string{"$org1MSP", "$orgMSP"},
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$orgMSP"}, // org2 gathers the low stock alerts
+}
+
+// RationPurchasedPayload is the payload emitted with rationPurchasedLog
+type RationPurchasedPayload struct {
+	EventPayload
+	RationCardNumber    string          `json:"rationCardNumber"`
+	NID                 string          `json:"nid"`
+	RationID            string          `json:"rationId"`
+	DistributionPointID string          `json:"distributionPointId"`
+	Quantity            int             `json:"quantity"`
+	Unit                datatypes.Unit  `json:"unit"` // Base unit of the ration category
+	AmountPaid          datatypes.Money `json:"amountPaid"`
+	SubsidyClaimed      datatypes.Money `json:"subsidyClaimed"`
+	BookingID           string          `json:"bookingId"` // Pickup booking the member presented the code of
+
+	// Who collected the ration, and the NID of the nominee if not the member
+	CollectedBy datatypes.Collector `json:"collectedBy"`
+	NomineeNID  string              `json:"nomineeNid,omitempty"`
+
+	// Mobile wallet payment of the amount paid, empty if paid in cash
+	PaymentProvider  datatypes.MFSProvider `json:"paymentProvider,omitempty"`
+	PaymentReference string                `json:"paymentReference,omitempty"`
+
+	// Set when the purchase made the stock cross its minimum level
+	LowStockAlert *LowStockAlertPayload `json:"lowStockAlert,omitempty"`
 }
@@ -1,6 +1,7 @@
 package eventtypes
 
 import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
 	"github.com/hyperledger-labs/cc-tools/events"
 )
 
@@ -12,3 +13,12 @@ var RationUpdatedLog = events.Event{
 	BaseLog:     "Ration updated",
 	Receivers:   []string{"$org2MSP", "$orgMSP"},
 }
+
+// RationUpdatedPayload is the payload emitted with rationUpdatedLog
+type RationUpdatedPayload struct {
+	EventPayload
+	RationID       string         `json:"rationId"`
+	QuantityBefore int            `json:"quantityBefore"`
+	QuantityAfter  int            `json:"quantityAfter"`
+	Unit           datatypes.Unit `json:"unit"` // Base unit of the ration category
+}
@@ -0,0 +1,26 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var ReimbursementClaimDecidedLog = events.Event{
+	Tag:         "reimbursementClaimDecidedLog",
+	Label:       "Reimbursement Claim Decided Log",
+	Description: "Log of a reimbursement claim approved or rejected by the ministry",
+	Type:        events.EventLog,
+	BaseLog:     "Reimbursement claim decided",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$orgMSP"},
+}
+
+// ReimbursementClaimDecidedPayload is the payload emitted with reimbursementClaimDecidedLog
+type ReimbursementClaimDecidedPayload struct {
+	EventPayload
+	DistributorID  string                             `json:"distributorId"`
+	Month          string                             `json:"month"`
+	Status         datatypes.ReimbursementClaimStatus `json:"status"`
+	AmountClaimed  datatypes.Money                    `json:"amountClaimed"`
+	AmountApproved datatypes.Money                    `json:"amountApproved"`
+	RejectedSales  int                                `json:"rejectedSales"`
+}
@@ -0,0 +1,24 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var ReimbursementClaimRaisedLog = events.Event{
+	Tag:         "reimbursementClaimRaisedLog",
+	Label:       "Reimbursement Claim Raised Log",
+	Description: "Log of a subsidy reimbursement claim raised by a distributor",
+	Type:        events.EventLog,
+	BaseLog:     "Reimbursement claim raised",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$orgMSP"},
+}
+
+// ReimbursementClaimRaisedPayload is the payload emitted with reimbursementClaimRaisedLog
+type ReimbursementClaimRaisedPayload struct {
+	EventPayload
+	DistributorID string          `json:"distributorId"`
+	Month         string          `json:"month"`
+	Sales         int             `json:"sales"`
+	AmountClaimed datatypes.Money `json:"amountClaimed"`
+}
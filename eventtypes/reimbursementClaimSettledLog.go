@@ -0,0 +1,24 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var ReimbursementClaimSettledLog = events.Event{
+	Tag:         "reimbursementClaimSettledLog",
+	Label:       "Reimbursement Claim Settled Log",
+	Description: "Log of the payment of a reimbursement claim",
+	Type:        events.EventLog,
+	BaseLog:     "Reimbursement claim settled",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$orgMSP"},
+}
+
+// ReimbursementClaimSettledPayload is the payload emitted with reimbursementClaimSettledLog
+type ReimbursementClaimSettledPayload struct {
+	EventPayload
+	DistributorID    string          `json:"distributorId"`
+	Month            string          `json:"month"`
+	AmountPaid       datatypes.Money `json:"amountPaid"`
+	PaymentReference string          `json:"paymentReference"`
+}
@@ -0,0 +1,22 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var ReimbursementClaimVerifiedLog = events.Event{
+	Tag:         "reimbursementClaimVerifiedLog",
+	Label:       "Reimbursement Claim Verified Log",
+	Description: "Log of a reimbursement claim verified against the ledger",
+	Type:        events.EventLog,
+	BaseLog:     "Reimbursement claim verified",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$orgMSP"},
+}
+
+// ReimbursementClaimVerifiedPayload is the payload emitted with reimbursementClaimVerifiedLog
+type ReimbursementClaimVerifiedPayload struct {
+	EventPayload
+	DistributorID string   `json:"distributorId"`
+	Month         string   `json:"month"`
+	Findings      []string `json:"findings"` // Discrepancies between the claim and the ledger
+}
@@ -0,0 +1,26 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var RestockRequestedLog = events.Event{
+	Tag:         "restockRequestedLog",
+	Label:       "Restock Requested Log",
+	Description: "Log of a restock request recorded from a low stock alert of a distribution point channel",
+	Type:        events.EventLog,
+	BaseLog:     "Restock requested",
+	Receivers:   []string{"$org2MSP", "$orgMSP"},
+}
+
+// RestockRequestedPayload is the payload emitted with restockRequestedLog
+type RestockRequestedPayload struct {
+	EventPayload
+	RequestID           string                   `json:"requestId"`
+	SourceChannel       string                   `json:"sourceChannel"`
+	DistributionPointID string                   `json:"distributionPointId"`
+	Category            datatypes.RationCategory `json:"category"`
+	SuggestedQuantity   int                      `json:"suggestedQuantity"`
+	Unit                datatypes.Unit           `json:"unit"`
+}
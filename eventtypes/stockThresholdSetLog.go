@@ -0,0 +1,28 @@
+package eventtypes
+
+import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/events"
+)
+
+var StockThresholdSetLog = events.Event{
+	Tag:         "stockThresholdSetLog",
+	Label:       "Stock Threshold Set Log",
+	Description: "Log of the minimum and target levels set for a stock",
+	Type:        events.EventLog,
+	BaseLog:     "Stock threshold set",
+	Receivers:   []string{"$org1MSP", "$org2MSP", "$orgMSP"}, // org2 gathers the low stock alerts
+}
+
+// StockThresholdSetPayload is the payload emitted with stockThresholdSetLog
+type StockThresholdSetPayload struct {
+	EventPayload
+	DistributionPointID string                   `json:"distributionPointId"`
+	Category            datatypes.RationCategory `json:"category"`
+	MinimumLevel        int                      `json:"minimumLevel"`
+	TargetLevel         int                      `json:"targetLevel"`
+	Unit                datatypes.Unit           `json:"unit"` // Base unit of the ration category
+
+	// Set when the current level is already under the new minimum level
+	LowStockAlert *LowStockAlertPayload `json:"lowStockAlert,omitempty"`
+}
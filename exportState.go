@@ -0,0 +1,211 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/assets"
+)
+
+// exportMetaColumns are the cc-tools metadata columns leading every exported file
+var exportMetaColumns = []string{"@key", "@lastTouchBy", "@lastTx", "@lastUpdated"}
+
+// nestedDataTypes are the object datatypes expanded into one column per field,
+// named after the prop tag and the field, e.g. address.district
+var nestedDataTypes = map[string]interface{}{
+	"address":        datatypes.Address{},
+	"contactInfo":    datatypes.ContactInfo{},
+	"operatingHours": datatypes.OperatingHours{},
+	"quantity":       datatypes.Quantity{},
+}
+
+// exportState reads a JSON dump of the world state and writes a CSV file per asset type to
+// outDir. The dump may be an array of assets, a CCAPI search response ({"result": [...]})
+// or an array of {"key", "value"} entries as found in a peer snapshot.
+func exportState(dumpPath, outDir string) error {
+	dump, err := os.ReadFile(dumpPath)
+	if err != nil {
+		return err
+	}
+	records, err := readStateDump(dump)
+	if err != nil {
+		return err
+	}
+
+	byType := map[string][]map[string]interface{}{}
+	for _, record := range records {
+		assetType, _ := record["@assetType"].(string)
+		if assetType == "" {
+			continue
+		}
+		byType[assetType] = append(byType[assetType], record)
+	}
+
+	err = os.MkdirAll(outDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	for _, assetType := range assetTypeList {
+		rows := byType[assetType.Tag]
+		if len(rows) == 0 {
+			continue
+		}
+
+		columns := exportColumns(assetType)
+		err := writeExportFile(filepath.Join(outDir, assetType.Tag+".csv"), columns, rows)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Exported %d %s asset(s)\n", len(rows), assetType.Tag)
+		delete(byType, assetType.Tag)
+	}
+	for assetType, rows := range byType {
+		fmt.Printf("Skipped %d asset(s) of unregistered type %s\n", len(rows), assetType)
+	}
+
+	return nil
+}
+
+// readStateDump extracts the asset records of a world state dump
+func readStateDump(dump []byte) ([]map[string]interface{}, error) {
+	var entries []interface{}
+	if err := json.Unmarshal(dump, &entries); err != nil {
+		var response struct {
+			Result []interface{} `json:"result"`
+		}
+		if err := json.Unmarshal(dump, &response); err != nil {
+			return nil, fmt.Errorf("dump must be a JSON array or a search response: %w", err)
+		}
+		entries = response.Result
+	}
+
+	records := []map[string]interface{}{}
+	for _, entry := range entries {
+		record, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		// Snapshot entries hold the asset in their value, possibly as a JSON string
+		if _, isAsset := record["@assetType"]; !isAsset {
+			switch value := record["value"].(type) {
+			case map[string]interface{}:
+				record = value
+			case string:
+				record = map[string]interface{}{}
+				if err := json.Unmarshal([]byte(value), &record); err != nil {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// exportColumns lists the columns of an asset type, expanding the nested datatypes
+func exportColumns(assetType assets.AssetType) []string {
+	columns := append([]string{}, exportMetaColumns...)
+	for _, prop := range assetType.Props {
+		nested, ok := nestedDataTypes[prop.DataType]
+		if !ok {
+			columns = append(columns, prop.Tag)
+			continue
+		}
+		for _, field := range jsonFields(nested) {
+			columns = append(columns, prop.Tag+"."+field)
+		}
+	}
+	return columns
+}
+
+// jsonFields lists the JSON names of the fields of a struct
+func jsonFields(value interface{}) []string {
+	fields := []string{}
+	structType := reflect.TypeOf(value)
+	for i := 0; i < structType.NumField(); i++ {
+		name := strings.Split(structType.Field(i).Tag.Get("json"), ",")[0]
+		if name == "" {
+			name = structType.Field(i).Name
+		}
+		fields = append(fields, name)
+	}
+	return fields
+}
+
+// writeExportFile writes the records of an asset type to a CSV file
+func writeExportFile(path string, columns []string, records []map[string]interface{}) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	err = writer.Write(columns)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			value, ok := record[column]
+			if !ok {
+				if prop, field, nested := strings.Cut(column, "."); nested {
+					object, _ := record[prop].(map[string]interface{})
+					value = object[field]
+				}
+			}
+			row[i] = exportCell(value)
+		}
+		err = writer.Write(row)
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// exportCell formats a value as a CSV cell. References are written as the referenced key,
+// lists are joined with semicolons and other objects are written as JSON.
+func exportCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case map[string]interface{}:
+		if key, ok := v["@key"].(string); ok {
+			return key
+		}
+	case []interface{}:
+		cells := []string{}
+		for _, item := range v {
+			cells = append(cells, exportCell(item))
+		}
+		return strings.Join(cells, ";")
+	}
+
+	cell, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(cell)
+}
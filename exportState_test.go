@@ -0,0 +1,112 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+)
+
+func TestReadStateDump(t *testing.T) {
+	want := []map[string]interface{}{
+		{"@assetType": "member", "@key": "member:1", "name": "Rahim"},
+	}
+	tests := []struct {
+		name string
+		dump string
+	}{
+		{"asset array", `[{"@assetType": "member", "@key": "member:1", "name": "Rahim"}]`},
+		{"search response", `{"result": [{"@assetType": "member", "@key": "member:1", "name": "Rahim"}]}`},
+		{"snapshot entries", `[{"key": "member:1", "value": {"@assetType": "member", "@key": "member:1", "name": "Rahim"}}]`},
+		{"snapshot entries with JSON string values", `[{"key": "member:1", "value": "{\"@assetType\": \"member\", \"@key\": \"member:1\", \"name\": \"Rahim\"}"}]`},
+		{"unreadable entries skipped", `[1, {"key": "x", "value": "not json"}, {"key": "y"}, {"@assetType": "member", "@key": "member:1", "name": "Rahim"}]`},
+	}
+	for _, tt := range tests {
+		got, err := readStateDump([]byte(tt.dump))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, want)
+		}
+	}
+
+	if _, err := readStateDump([]byte(`{"members": []}`)); err != nil {
+		t.Errorf("object without result: %v", err)
+	}
+	if _, err := readStateDump([]byte(`not json`)); err == nil {
+		t.Error("invalid dump read without error")
+	}
+}
+
+func TestExportColumns(t *testing.T) {
+	assetType := assets.AssetType{
+		Tag: "sample",
+		Props: []assets.AssetProp{
+			{Tag: "id", DataType: "string"},
+			{Tag: "address", DataType: "address"},
+			{Tag: "quantity", DataType: "quantity"},
+			{Tag: "tags", DataType: "[]string"},
+		},
+	}
+	want := []string{
+		"@key", "@lastTouchBy", "@lastTx", "@lastUpdated",
+		"id",
+		"address.addressLine", "address.union", "address.upazila", "address.district", "address.division", "address.postCode",
+		"quantity.value", "quantity.unit",
+		"tags",
+	}
+	if got := exportColumns(assetType); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestWriteExportFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sample.csv")
+	columns := []string{"@key", "address.district", "quantity.value", "quantity.unit"}
+	records := []map[string]interface{}{
+		{
+			"@key":     "sample:1",
+			"address":  map[string]interface{}{"district": "Gazipur"},
+			"quantity": map[string]interface{}{"value": 2.5, "unit": "kg"},
+		},
+		{"@key": "sample:2"},
+	}
+	if err := writeExportFile(path, columns, records); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "@key,address.district,quantity.value,quantity.unit\nsample:1,Gazipur,2.5,kg\nsample:2,,,\n"
+	if string(got) != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestExportCell(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"nil", nil, ""},
+		{"string", "Rahim", "Rahim"},
+		{"integer", 12.0, "12"},
+		{"decimal", 12.5, "12.5"},
+		{"bool", true, "true"},
+		{"reference", map[string]interface{}{"@assetType": "member", "@key": "member:1"}, "member:1"},
+		{"list", []interface{}{"a", 1.0, map[string]interface{}{"@key": "ration:1"}}, "a;1;ration:1"},
+		{"object", map[string]interface{}{"value": 2.0, "unit": "kg"}, `{"unit":"kg","value":2}`},
+	}
+	for _, tt := range tests {
+		if got := exportCell(tt.value); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
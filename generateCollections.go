@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/assettypes"
 )
 
 type ArrayFlags []string
@@ -39,6 +41,10 @@ func generateCollection(orgs ArrayFlags) {
 				MemberOnlyRead:    true,
 				Policy:            generatePolicy(a.Readers, orgs),
 			}
+			if config, ok := assettypes.Collections[a.Tag]; ok {
+				elem.BlockToLive = config.BlockToLive
+				elem.MemberOnlyRead = config.MemberOnlyRead
+			}
 			collection = append(collection, elem)
 		}
 	}
@@ -9,6 +9,7 @@ require (
 	github.com/hyperledger-labs/cc-tools v1.0.0
 	github.com/hyperledger/fabric-chaincode-go v0.0.0-20210603161043-af0e3898842a
 	github.com/hyperledger/fabric-protos-go v0.0.0-20210528200356-82833ecdac31
+	google.golang.org/protobuf v1.34.2
 )
 
 require (
@@ -44,7 +45,6 @@ require (
 	golang.org/x/text v0.15.0 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20230525234030-28d5490b6b19 // indirect
 	google.golang.org/grpc v1.57.0 // indirect
-	google.golang.org/protobuf v1.34.2 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
 	honnef.co/go/tools v0.0.1-2020.1.4 // indirect
 )
@@ -10,6 +10,7 @@ import (
 	"github.com/hyperledger-labs/cc-tools-demo/chaincode/assettypes"
 	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
 	"github.com/hyperledger-labs/cc-tools-demo/chaincode/header"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/txdefs"
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/events"
 	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
@@ -40,6 +41,12 @@ func SetupCC() error {
 	}
 	assets.InitAssetList(append(assetTypeList, assettypes.CustomAssets...))
 
+	err = permissionStartupCheck()
+	if err != nil {
+		fmt.Printf("Error checking asset permissions: %s", err)
+		return err
+	}
+
 	events.InitEventList(eventTypeList)
 
 	return nil
@@ -50,12 +57,36 @@ func main() {
 	// Generate collection json
 	genFlag := flag.Bool("g", false, "Enable collection generation")
 	flag.Bool("orgs", false, "List of orgs to generate collection for")
+	membersCSV := flag.String("members-csv", "", "Convert a CSV file of members into bulkRegisterMembers payloads")
+	batchSize := flag.Int("batch-size", 100, fmt.Sprintf("Number of members per payload, at most %d", txdefs.MaxMemberBatchSize))
+	exportDump := flag.String("export", "", "Export a JSON dump of the world state into a CSV file per asset type")
+	exportDir := flag.String("export-dir", ".", "Directory where the exported CSV files are written")
 	flag.Parse()
 	if *genFlag {
 		listOrgs := flag.Args()
 		generateCollection(listOrgs)
 		return
 	}
+	if *membersCSV != "" {
+		if *batchSize < 1 || *batchSize > txdefs.MaxMemberBatchSize {
+			fmt.Printf("Batch size must be between 1 and %d\n", txdefs.MaxMemberBatchSize)
+			os.Exit(1)
+		}
+		err := generateMemberBatches(*membersCSV, *batchSize)
+		if err != nil {
+			fmt.Printf("Error converting members: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if *exportDump != "" {
+		err := exportState(*exportDump, *exportDir)
+		if err != nil {
+			fmt.Printf("Error exporting state: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	log.Printf("Starting chaincode %s version %s\n", header.Name, header.Version)
 
@@ -181,6 +212,12 @@ func InitFunc(stub shim.ChaincodeStubInterface) (response pb.Response) {
 		return
 	}
 
+	err = eventStartupCheck()
+	if err != nil {
+		response = err.GetErrorResponse()
+		return
+	}
+
 	response = shim.Success(nil)
 	return
 }
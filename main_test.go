@@ -1,8 +1,14 @@
 package main
 
 import (
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"log"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hyperledger-labs/cc-tools/mock"
@@ -26,3 +32,213 @@ func TestMain(m *testing.M) {
 
 	os.Exit(m.Run())
 }
+
+// TestTxEventList checks that txEventList declares exactly the events each transaction of txList
+// calls, following the helpers of txdefs its routine calls. Proposals are credited with the events
+// of every transaction requiring approval, which they may execute.
+func TestTxEventList(t *testing.T) {
+	fset := token.NewFileSet()
+	files, err := filepath.Glob("txdefs/*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each top-level declaration of txdefs, with the events it calls and the declarations it uses
+	decls := map[string]*txdefsDecl{}
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		imports := map[string]bool{}
+		for _, spec := range f.Imports {
+			path, _ := strconv.Unquote(spec.Path.Value)
+			name := filepath.Base(path)
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			imports[name] = true
+		}
+		for _, d := range f.Decls {
+			switch d := d.(type) {
+			case *ast.FuncDecl:
+				decls[d.Name.Name] = &txdefsDecl{node: d, imports: imports}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					if spec, ok := spec.(*ast.ValueSpec); ok {
+						for _, name := range spec.Names {
+							decls[name.Name] = &txdefsDecl{node: spec, imports: imports}
+						}
+					}
+				}
+			}
+		}
+	}
+	for _, decl := range decls {
+		decl.inspect(decls)
+	}
+
+	// Match the transactions of txList with the txdefs variables they are declared by
+	src, err := parser.ParseFile(fset, "txList.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var txVars []string
+	ast.Inspect(src, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "txdefs" {
+				txVars = append(txVars, sel.Sel.Name)
+			}
+		}
+		return true
+	})
+	if len(txVars) != len(txList) {
+		t.Fatalf("txList.go lists %d transactions, txList holds %d", len(txVars), len(txList))
+	}
+	isTx := map[string]bool{}
+	for _, name := range txVars {
+		isTx[name] = true
+	}
+
+	// Events of the transactions requiring approval, executed by proposals
+	var approvalEvents [][]string
+	for _, name := range txVars {
+		if decls[name].wrapped {
+			approvalEvents = append(approvalEvents, decls[name].emitted(decls, isTx, name)...)
+		}
+	}
+
+	for i, name := range txVars {
+		txTag := txList[i].Tag
+		decl, ok := decls[name]
+		if !ok {
+			t.Errorf("tx %s is not declared in txdefs", name)
+			continue
+		}
+		emitted := decl.emitted(decls, isTx, name)
+		if decl.reaches(decls, isTx, name, "executeProposal") {
+			emitted = append(emitted, approvalEvents...)
+		}
+
+		declared := map[string]bool{}
+		for _, eventTag := range txEventList[txTag] {
+			declared[eventTag] = true
+		}
+		called := map[string]bool{}
+		for _, alternatives := range emitted {
+			found := false
+			for _, eventTag := range alternatives {
+				called[eventTag] = true
+				found = found || declared[eventTag]
+			}
+			if !found {
+				t.Errorf("tx %s emits %s, which is missing from txEventList", txTag, strings.Join(alternatives, " or "))
+			}
+		}
+		for _, eventTag := range txEventList[txTag] {
+			if !called[eventTag] {
+				t.Errorf("txEventList declares %s for tx %s, which never emits it", eventTag, txTag)
+			}
+		}
+	}
+}
+
+// txdefsDecl is a top-level declaration of txdefs
+type txdefsDecl struct {
+	node    ast.Node
+	imports map[string]bool // Package names of its file
+	wrapped bool            // A transaction wrapped in requireApproval
+	events  [][]string      // Tags of each event call, any of which is declared when passed through a variable
+	uses    []string        // Other declarations it uses
+}
+
+// inspect collects the event calls of the declaration and the declarations it uses
+func (d *txdefsDecl) inspect(decls map[string]*txdefsDecl) {
+	// String literals assigned to each variable, for event tags passed through one
+	assigned := map[string][]string{}
+	ast.Inspect(d.node, func(n ast.Node) bool {
+		if assign, ok := n.(*ast.AssignStmt); ok && len(assign.Lhs) == len(assign.Rhs) {
+			for i, lhs := range assign.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				lit, isLit := assign.Rhs[i].(*ast.BasicLit)
+				if ok && isLit && lit.Kind == token.STRING {
+					value, _ := strconv.Unquote(lit.Value)
+					assigned[ident.Name] = append(assigned[ident.Name], value)
+				}
+			}
+		}
+		return true
+	})
+
+	if spec, ok := d.node.(*ast.ValueSpec); ok && len(spec.Values) == 1 {
+		if call, ok := spec.Values[0].(*ast.CallExpr); ok {
+			if fun, ok := call.Fun.(*ast.Ident); ok && fun.Name == "requireApproval" {
+				d.wrapped = true
+			}
+		}
+	}
+
+	ast.Inspect(d.node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			pkg, ok := n.X.(*ast.Ident)
+			if !ok || !d.imports[pkg.Name] {
+				return true
+			}
+			return false // Identifiers of other packages
+		case *ast.CallExpr:
+			sel, ok := n.Fun.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "CallEvent" || len(n.Args) < 2 {
+				return true
+			}
+			if pkg, ok := sel.X.(*ast.Ident); !ok || pkg.Name != "events" {
+				return true
+			}
+			switch arg := n.Args[1].(type) {
+			case *ast.BasicLit:
+				value, _ := strconv.Unquote(arg.Value)
+				d.events = append(d.events, []string{value})
+			case *ast.Ident:
+				d.events = append(d.events, assigned[arg.Name])
+			}
+		case *ast.Ident:
+			if _, ok := decls[n.Name]; ok {
+				d.uses = append(d.uses, n.Name)
+			}
+		}
+		return true
+	})
+}
+
+// emitted returns the event calls of the declaration and of the declarations it uses, other
+// than transactions
+func (d *txdefsDecl) emitted(decls map[string]*txdefsDecl, isTx map[string]bool, name string) [][]string {
+	var events [][]string
+	d.walk(decls, isTx, name, map[string]bool{}, func(decl *txdefsDecl) {
+		events = append(events, decl.events...)
+	})
+	return events
+}
+
+// reaches checks whether the declaration uses target, directly or through other declarations
+func (d *txdefsDecl) reaches(decls map[string]*txdefsDecl, isTx map[string]bool, name, target string) bool {
+	visited := map[string]bool{}
+	d.walk(decls, isTx, name, visited, func(*txdefsDecl) {})
+	return visited[target]
+}
+
+func (d *txdefsDecl) walk(decls map[string]*txdefsDecl, isTx map[string]bool, name string, visited map[string]bool, visit func(*txdefsDecl)) {
+	if visited[name] {
+		return
+	}
+	visited[name] = true
+	visit(d)
+	for _, use := range d.uses {
+		if !isTx[use] {
+			decls[use].walk(decls, isTx, use, visited, visit)
+		}
+	}
+}
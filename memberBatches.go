@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// memberBatch is the payload of a bulkRegisterMembers call
+type memberBatch struct {
+	Members []map[string]interface{} `json:"members"`
+}
+
+// generateMemberBatches converts a CSV file of members into bulkRegisterMembers payloads,
+// written to members-batch-<n>.json files. The CSV header names the member props. Fields of
+// the address and contact information are prefixed with the prop tag, e.g. address.district.
+// Values are sent as is when they cannot be converted, for the chaincode to report the row.
+func generateMemberBatches(csvPath string, batchSize int) error {
+	file, err := os.Open(csvPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	batches := 0
+	rows := 0
+	batch := memberBatch{Members: []map[string]interface{}{}}
+	flush := func() error {
+		if len(batch.Members) == 0 {
+			return nil
+		}
+		batches++
+		b, err := json.MarshalIndent(batch, "", "  ")
+		if err != nil {
+			return err
+		}
+		err = os.WriteFile(fmt.Sprintf("members-batch-%03d.json", batches), b, 0644)
+		if err != nil {
+			return err
+		}
+		batch = memberBatch{Members: []map[string]interface{}{}}
+		return nil
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read CSV row %d: %w", rows+2, err)
+		}
+		rows++
+
+		batch.Members = append(batch.Members, memberRecord(header, record))
+		if len(batch.Members) == batchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Converted %d member(s) into %d batch(es)\n", rows, batches)
+	return nil
+}
+
+// memberRecord builds a member record from a CSV row, skipping empty cells
+func memberRecord(header, row []string) map[string]interface{} {
+	member := map[string]interface{}{}
+	for i, column := range header {
+		if i >= len(row) || strings.TrimSpace(row[i]) == "" {
+			continue
+		}
+		cell := strings.TrimSpace(row[i])
+
+		if prop, field, ok := strings.Cut(column, "."); ok {
+			object, _ := member[prop].(map[string]interface{})
+			if object == nil {
+				object = map[string]interface{}{}
+				member[prop] = object
+			}
+			object[field] = cell
+			continue
+		}
+
+		switch column {
+		case "height", "familySize", "income":
+			if number, err := strconv.ParseFloat(cell, 64); err == nil {
+				member[column] = number
+				continue
+			}
+		case "disabilityStatus", "pregnant":
+			if flag, err := strconv.ParseBool(cell); err == nil {
+				member[column] = flag
+				continue
+			}
+		}
+		member[column] = cell
+	}
+	return member
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMemberRecord(t *testing.T) {
+	header := []string{"nid", "name", "familySize", "disabilityStatus", "address.division", "address.district"}
+	tests := []struct {
+		name string
+		row  []string
+		want map[string]interface{}
+	}{
+		{
+			"converted values",
+			[]string{"1234567890", " Rahim ", "4", "true", "Dhaka", "Gazipur"},
+			map[string]interface{}{
+				"nid":              "1234567890",
+				"name":             "Rahim",
+				"familySize":       4.0,
+				"disabilityStatus": true,
+				"address":          map[string]interface{}{"division": "Dhaka", "district": "Gazipur"},
+			},
+		},
+		{
+			"empty cells skipped",
+			[]string{"1234567890", "Rahim", "", "", "", ""},
+			map[string]interface{}{"nid": "1234567890", "name": "Rahim"},
+		},
+		{
+			"short row",
+			[]string{"1234567890"},
+			map[string]interface{}{"nid": "1234567890"},
+		},
+		{
+			"unconverted values sent as is",
+			[]string{"1234567890", "Rahim", "four", "maybe", "", ""},
+			map[string]interface{}{"nid": "1234567890", "name": "Rahim", "familySize": "four", "disabilityStatus": "maybe"},
+		},
+	}
+	for _, tt := range tests {
+		got := memberRecord(header, tt.row)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateMemberBatches(t *testing.T) {
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "members.csv")
+	csv := "nid, name\n1000000001, A\n1000000002, B\n1000000003, C\n"
+	if err := os.WriteFile(csvPath, []byte(csv), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	if err := generateMemberBatches(csvPath, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][]string{
+		"members-batch-001.json": {"1000000001", "1000000002"},
+		"members-batch-002.json": {"1000000003"},
+	}
+	for file, nids := range want {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var batch memberBatch
+		if err := json.Unmarshal(b, &batch); err != nil {
+			t.Fatal(err)
+		}
+		got := []string{}
+		for _, member := range batch.Members {
+			got = append(got, member["nid"].(string))
+		}
+		if !reflect.DeepEqual(got, nids) {
+			t.Errorf("%s: got members %v, want %v", file, got, nids)
+		}
+	}
+	if _, err := os.Stat("members-batch-003.json"); !os.IsNotExist(err) {
+		t.Errorf("unexpected third batch: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package mfs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+)
+
+// FileVerifier is a Verifier standing in for the providers in tests and local networks.
+// It knows the payments listed in a JSON file, an array of receipts such as
+// [{"provider": "bKash", "reference": "9A1B2C3D4E", "amount": "30.00"}].
+type FileVerifier struct {
+	payments map[string]datatypes.Money
+}
+
+// NewFileVerifier reads the payments of a JSON file
+func NewFileVerifier(path string) (*FileVerifier, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var receipts []datatypes.PaymentReceipt
+	err = json.Unmarshal(data, &receipts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payments file %s: %w", path, err)
+	}
+
+	verifier := &FileVerifier{payments: map[string]datatypes.Money{}}
+	for _, receipt := range receipts {
+		verifier.payments[paymentKey(receipt)] = receipt.Amount
+	}
+	return verifier, nil
+}
+
+// Verify checks the receipt is listed in the file with the same amount
+func (v *FileVerifier) Verify(ctx context.Context, receipt datatypes.PaymentReceipt) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	amount, ok := v.payments[paymentKey(receipt)]
+	if !ok {
+		return fmt.Errorf("%s receipt %s: %w", receipt.Provider, receipt.Reference, ErrUnknownReceipt)
+	}
+	if amount != receipt.Amount {
+		return fmt.Errorf("%s receipt %s pays %s, not %s: %w", receipt.Provider, receipt.Reference, amount, receipt.Amount, ErrAmountMismatch)
+	}
+	return nil
+}
+
+// paymentKey identifies a payment the way the chaincode does, by provider and reference
+func paymentKey(receipt datatypes.PaymentReceipt) string {
+	return string(receipt.Provider) + ":" + strings.ToUpper(strings.TrimSpace(receipt.Reference))
+}
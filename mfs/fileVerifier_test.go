@@ -0,0 +1,39 @@
+package mfs
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+)
+
+func TestFileVerifier(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "payments.json")
+	err := os.WriteFile(path, []byte(`[{"provider": "bKash", "reference": "9A1B2C3D4E", "amount": "30.00"}]`), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := NewFileVerifier(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		receipt datatypes.PaymentReceipt
+		want    error
+	}{
+		{"listed", datatypes.PaymentReceipt{Provider: datatypes.MFSProviderBKash, Reference: " 9a1b2c3d4e", Amount: 3000}, nil},
+		{"other amount", datatypes.PaymentReceipt{Provider: datatypes.MFSProviderBKash, Reference: "9A1B2C3D4E", Amount: 2000}, ErrAmountMismatch},
+		{"other provider", datatypes.PaymentReceipt{Provider: datatypes.MFSProviderNagad, Reference: "9A1B2C3D4E", Amount: 3000}, ErrUnknownReceipt},
+	}
+	for _, tt := range tests {
+		err := verifier.Verify(context.Background(), tt.receipt)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// Package mfs checks mobile financial service payment receipts with their provider before
+// the purchases they pay are submitted to the chaincode. It runs off-chain, as endorsement
+// must stay deterministic.
+package mfs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+)
+
+var (
+	// ErrUnknownReceipt is returned for a receipt the provider has no record of
+	ErrUnknownReceipt = errors.New("receipt is unknown to the provider")
+
+	// ErrAmountMismatch is returned for a receipt whose amount differs from the payment made
+	ErrAmountMismatch = errors.New("receipt amount differs from the payment")
+)
+
+// Verifier checks that a payment receipt was issued by its provider for its amount
+type Verifier interface {
+	Verify(ctx context.Context, receipt datatypes.PaymentReceipt) error
+}
@@ -0,0 +1,77 @@
+package main
+
+// txEventList maps each transaction tag to the tags of the events its routine emits.
+// It must be kept in sync with the transactions, as eventStartupCheck relies on it
+// to refuse starting the chaincode if a transaction is missing from it, emits an
+// unregistered event, or if a registered event is emitted by no transaction.
+var txEventList = map[string][]string{
+	"createAsset":              {"assetCreatedLog"},
+	"updateAsset":              {"assetUpdatedLog"},
+	"deleteAsset":              {"assetDeletedLog", "rationDeletedLog"},
+	"issueRationCard":          {"rationCardIssuedLog"},
+	"updateMemberInfo":         {"memberInfoUpdatedLog"},
+	"replenishInventory":       {"inventoryReplenishedLog"},
+	"createRation":             {"rationCreatedLog"},
+	"updateRation":             {"rationUpdatedLog"},
+	"setPickupSchedule":        {"pickupScheduleSetLog"},
+	"getPickupSchedule":        {"pickupScheduleGetLog"},
+	"createDistributor":        {"distributorCreatedLog"},
+	"createDistributionPoint":  {"distributionPointCreatedLog"},
+	"createInventory":          {"inventoryCreatedLog"},
+	"buyRation":                {"rationPurchasedLog"},
+	"raiseLowStockAlert":       {"lowStockAlert"},
+	"receiveLowStockAlert":     {"restockRequestedLog"},
+	"setStockThreshold":        {"stockThresholdSetLog"},
+	"createPurchaseOrder":      {"purchaseOrderCreatedLog"},
+	"approvePurchaseOrder":     {"purchaseOrderApprovedLog"},
+	"recordDelivery":           {"deliveryRecordedLog"},
+	"setApprovalPolicy":        {"approvalPolicySetLog"},
+	"expireProposals":          {"proposalsExpiredLog"},
+	"assignOperator":           {"operatorAssignedLog"},
+	"unassignOperator":         {"operatorUnassignedLog"},
+	"archiveMember":            {"assetArchivedLog"},
+	"restoreMember":            {"assetRestoredLog"},
+	"archiveRation":            {"assetArchivedLog"},
+	"restoreRation":            {"assetRestoredLog"},
+	"bulkRegisterMembers":      {"membersRegisteredLog"},
+	"registerAdminArea":        {"adminAreaRegisteredLog"},
+	"setPriceSchedule":         {"priceScheduleSetLog"},
+	"raiseReimbursementClaim":  {"reimbursementClaimRaisedLog"},
+	"verifyReimbursementClaim": {"reimbursementClaimVerifiedLog"},
+	"decideReimbursementClaim": {"reimbursementClaimDecidedLog"},
+	"settleReimbursementClaim": {"reimbursementClaimSettledLog"},
+	"bookPickup":               {"pickupBookedLog"},
+	"issuePickupCode":          {"pickupCodeIssuedLog"},
+	"verifyPickupCode":         {"pickupCodeCheckedLog"},
+	"completePickup":           {"pickupCompletedLog"},
+	"rotateIssuerKey":          {"issuerKeyRotatedLog"},
+	"issueCardToken":           {"cardTokenIssuedLog"},
+	"registerOfflineDevice":    {"offlineDeviceRegisteredLog"},
+	"submitOfflineBatch":       {"offlineBatchReplayedLog"},
+	"resolveOfflineException":  {"offlineExceptionResolvedLog"},
+	"bindBiometric":            {"biometricBoundLog"},
+	"verifyBeneficiary":        {"beneficiaryVerifiedLog"},
+	"addNominee":               {"nomineeAddedLog"},
+	"removeNominee":            {"nomineeRemovedLog"},
+	"setPriorityClass":         {"priorityClassSetLog"},
+
+	// Read-only transactions emit no events
+	"readRation":                          nil,
+	"readTotalRationsByDistributionPoint": nil,
+	"getPermissionMatrix":                 nil,
+	"search":                              nil,
+	"readAsset":                           nil,
+	"readMemberHistory":                   nil,
+	"readRationHistory":                   nil,
+	"readDistributionPointHistory":        nil,
+	"readDistributorHistory":              nil,
+	"getDistributionPointStockAsOf":       nil,
+	"getAssetsByAdminArea":                nil,
+	"getRegionReport":                     nil,
+	"getSubsidyLiability":                 nil,
+	"getPriorityMembers":                  nil,
+
+	// Proposals emit the events of the transactions requiring approval they execute
+	"createProposal":  {"proposalCreatedLog", "rationCardIssuedLog", "inventoryReplenishedLog", "stockThresholdSetLog", "purchaseOrderApprovedLog", "priceScheduleSetLog", "approvalPolicySetLog", "assetArchivedLog", "priorityClassSetLog"},
+	"approveProposal": {"proposalApprovedLog", "rationCardIssuedLog", "inventoryReplenishedLog", "stockThresholdSetLog", "purchaseOrderApprovedLog", "priceScheduleSetLog", "approvalPolicySetLog", "assetArchivedLog", "priorityClassSetLog"},
+}
@@ -7,9 +7,9 @@ import (
 )
 
 var txList = []tx.Transaction{
-	tx.CreateAsset,
-	tx.UpdateAsset,
-	tx.DeleteAsset,
+	txdefs.CreateAsset,
+	txdefs.UpdateAsset,
+	txdefs.DeleteAsset,
 
 	txdefs.IssueRationCard,
 	txdefs.UpdateMemberInfo,
@@ -25,6 +25,56 @@ var txList = []tx.Transaction{
 	txdefs.CreateInventory,
 	txdefs.SetPickupSchedule, // Add the new transaction
 	txdefs.GetPickupSchedule, // Add the new transaction
+	txdefs.BuyRation,
+	txdefs.RaiseLowStockAlert,
+	txdefs.ReceiveLowStockAlert,
+	txdefs.SetStockThreshold,
+	txdefs.CreatePurchaseOrder,
+	txdefs.ApprovePurchaseOrder,
+	txdefs.RecordDelivery,
+	txdefs.SetApprovalPolicy,
+	txdefs.CreateProposal,
+	txdefs.ApproveProposal,
+	txdefs.ExpireProposals,
+	txdefs.AssignOperator,
+	txdefs.UnassignOperator,
+	txdefs.GetPermissionMatrix,
+	txdefs.ArchiveMember,
+	txdefs.RestoreMember,
+	txdefs.ArchiveRation,
+	txdefs.RestoreRation,
+	txdefs.Search,
+	txdefs.ReadMemberHistory,
+	txdefs.ReadRationHistory,
+	txdefs.ReadDistributionPointHistory,
+	txdefs.ReadDistributorHistory,
+	txdefs.ReadAsset,
+	txdefs.GetDistributionPointStockAsOf,
+	txdefs.BulkRegisterMembers,
+	txdefs.RegisterAdminArea,
+	txdefs.GetAssetsByAdminArea,
+	txdefs.GetRegionReport,
+	txdefs.SetPriceSchedule,
+	txdefs.GetSubsidyLiability,
+	txdefs.RaiseReimbursementClaim,
+	txdefs.VerifyReimbursementClaim,
+	txdefs.DecideReimbursementClaim,
+	txdefs.SettleReimbursementClaim,
+	txdefs.BookPickup,
+	txdefs.IssuePickupCode,
+	txdefs.VerifyPickupCode,
+	txdefs.CompletePickup,
+	txdefs.RotateIssuerKey,
+	txdefs.IssueCardToken,
+	txdefs.RegisterOfflineDevice,
+	txdefs.SubmitOfflineBatch,
+	txdefs.ResolveOfflineException,
+	txdefs.BindBiometric,
+	txdefs.VerifyBeneficiary,
+	txdefs.AddNominee,
+	txdefs.RemoveNominee,
+	txdefs.SetPriorityClass,
+	txdefs.GetPriorityMembers,
 }
 
 /*
@@ -0,0 +1,188 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// AddNominee authorizes a person to collect the rations of a member for a validity period.
+// A removed or expired nominee can be added again; a nominee already collecting for
+// MaxCardsPerNominee other members is refused.
+var AddNominee = tx.Transaction{
+	Tag:         "addNominee",
+	Label:       "Add Nominee",
+	Description: "Authorize a person to collect the rations of a member",
+	Method:      "POST",
+	Callers: []accesscontrol.Caller{ // Only org1 admin can call this transaction
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "member",
+			Label:       "Member",
+			Description: "Member the nominee collects for",
+			DataType:    "->member",
+			Required:    true,
+		},
+		{
+			Tag:         "nid",
+			Label:       "Nominee NID",
+			Description: "Nominee NID",
+			DataType:    "nid",
+			Required:    true,
+		},
+		{
+			Tag:         "name",
+			Label:       "Nominee Name",
+			Description: "Nominee Name",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "relationship",
+			Label:       "Relationship",
+			Description: "Relationship of the nominee to the member",
+			DataType:    "string",
+		},
+		{
+			Tag:         "validFrom",
+			Label:       "Valid From",
+			Description: "Start of the authorization, now if empty",
+			DataType:    "datetime",
+		},
+		{
+			Tag:         "validUntil",
+			Label:       "Valid Until",
+			Description: "End of the authorization",
+			DataType:    "datetime",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		memberKey, ok := req["member"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter member must be an asset")
+		}
+		nid, _ := req["nid"].(string)
+		name, _ := req["name"].(string)
+		relationship, _ := req["relationship"].(string)
+		validUntil, _ := req["validUntil"].(time.Time)
+
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+		now := txTimestamp.AsTime()
+		validFrom, ok := req["validFrom"].(time.Time)
+		if !ok {
+			validFrom = now
+		}
+		if !validUntil.After(validFrom) {
+			return nil, errors.NewCCError("validUntil must be after validFrom", http.StatusBadRequest)
+		}
+		if !validUntil.After(now) {
+			return nil, errors.NewCCError("validUntil is over", http.StatusBadRequest)
+		}
+
+		memberMap, err := memberKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get member from the ledger", err.Status())
+		}
+		if archived, _ := memberMap["archived"].(bool); archived {
+			return nil, errors.NewCCError("member is archived", http.StatusConflict)
+		}
+		principalNid, _ := memberMap["nid"].(string)
+		if nid == principalNid {
+			return nil, errors.NewCCError("a member cannot be their own nominee", http.StatusBadRequest)
+		}
+
+		nomineeMap := map[string]interface{}{
+			"@assetType":   "nominee",
+			"principal":    memberKey,
+			"nid":          nid,
+			"name":         name,
+			"relationship": relationship,
+			"validFrom":    validFrom,
+			"validUntil":   validUntil,
+			"removed":      false,
+		}
+		nomineeKey, err := assets.NewKey(nomineeMap)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build nominee key")
+		}
+		exists, err := nomineeKey.ExistsInLedger(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to check nominee existence")
+		}
+		if exists {
+			existingMap, err := nomineeKey.GetMap(stub)
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to get nominee from the ledger")
+			}
+			removed, _ := existingMap["removed"].(bool)
+			if !removed && scheduleDate(existingMap["validUntil"]).After(now) {
+				return nil, errors.NewCCError(fmt.Sprintf("%s is already a nominee of the member", nid), http.StatusConflict)
+			}
+		}
+
+		cards, err := nomineeCards(stub, nid, memberKey.Key(), now)
+		if err != nil {
+			return nil, err
+		}
+		if cards >= MaxCardsPerNominee {
+			return nil, errors.NewCCError(fmt.Sprintf("%s already collects for %d members", nid, cards), http.StatusConflict)
+		}
+
+		nomineeAsset, err := assets.NewAsset(nomineeMap)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build nominee")
+		}
+		// A nominee added again replaces the removed or expired authorization
+		putNomineeMap, err := nomineeAsset.Put(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to record nominee")
+		}
+
+		nomineeJSON, nerr := json.Marshal(putNomineeMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "nomineeAddedLog", nomineeAsset.Key(), fmt.Sprintf("Nominee %s added for member %s", nid, principalNid))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.NomineeAddedPayload{
+			EventPayload: eventPayload,
+			PrincipalNID: principalNid,
+			NomineeNID:   nid,
+			ValidFrom:    validFrom.Format(time.RFC3339),
+			ValidUntil:   validUntil.Format(time.RFC3339),
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "nomineeAddedLog", logMsg)
+
+		return nomineeJSON, nil
+	},
+}
@@ -0,0 +1,91 @@
+package txdefs
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+)
+
+// adminAreaPathSeparator joins the names of an administrative area and its ancestors
+const adminAreaPathSeparator = "/"
+
+// adminAreaKey builds the key of the administrative area with the given path
+func adminAreaKey(path string) (assets.Key, errors.ICCError) {
+	return assets.NewKey(map[string]interface{}{
+		"@assetType": "adminArea",
+		"path":       path,
+	})
+}
+
+// checkAddress rejects addresses whose administrative areas are not in the reference table.
+// Areas are registered under their parent, so checking the deepest one covers its ancestors.
+func checkAddress(stub *sw.StubWrapper, address datatypes.Address) errors.ICCError {
+	path := strings.Join(address.AreaNames(), adminAreaPathSeparator)
+	key, err := adminAreaKey(path)
+	if err != nil {
+		return errors.WrapError(err, "failed to build administrative area key")
+	}
+	exists, err := key.ExistsInLedger(stub)
+	if err != nil {
+		return errors.WrapError(err, "failed to check administrative area")
+	}
+	if !exists {
+		return errors.NewCCError(fmt.Sprintf("administrative area %s is not registered", path), http.StatusBadRequest)
+	}
+	return nil
+}
+
+// checkAssetAddresses checks the address props found in the values of an asset, or of an update of it
+func checkAssetAddresses(stub *sw.StubWrapper, assetTypeTag string, values map[string]interface{}) errors.ICCError {
+	assetType := assets.FetchAssetType(assetTypeTag)
+	if assetType == nil {
+		return nil
+	}
+
+	for _, prop := range assetType.Props {
+		value, ok := values[prop.Tag]
+		if prop.DataType != "address" || !ok || value == nil {
+			continue
+		}
+		_, parsed, err := assets.FetchDataType("address").Parse(value)
+		if err != nil {
+			return errors.WrapErrorWithStatus(err, fmt.Sprintf("invalid %s", prop.Tag), err.Status())
+		}
+		err = checkAddress(stub, parsed.(datatypes.Address))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// adminAreaSelector builds the CouchDB selector of the assets whose address lies in the
+// area with the given path. Area names are only unique under their parent, so every level
+// down to the area is matched.
+func adminAreaSelector(assetType, path string) map[string]interface{} {
+	selector := map[string]interface{}{
+		"@assetType": assetType,
+	}
+	for i, name := range strings.Split(path, adminAreaPathSeparator) {
+		selector["address."+datatypes.AdminAreaLevel(i).AddressField()] = name
+	}
+	return selector
+}
+
+// addressPath returns the path of the deepest administrative area of an address value,
+// or an empty string if the value is not a valid address
+func addressPath(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	_, parsed, err := assets.FetchDataType("address").Parse(value)
+	if err != nil {
+		return ""
+	}
+	return strings.Join(parsed.(datatypes.Address).AreaNames(), adminAreaPathSeparator)
+}
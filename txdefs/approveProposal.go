@@ -0,0 +1,142 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var ApproveProposal = tx.Transaction{
+	Tag:         "approveProposal",
+	Label:       "Approve Proposal",
+	Description: "Approve a proposal, executing its transaction once the approval policy threshold is met",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "org3MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "proposal",
+			Label:       "Proposal",
+			Description: "Proposal",
+			DataType:    "->proposal",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		proposalKey, ok := req["proposal"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter proposal must be an asset")
+		}
+
+		proposalMap, err := proposalKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get proposal from the ledger", err.Status())
+		}
+		if proposalMap["status"] != "open" {
+			return nil, errors.NewCCError("proposal is not open", http.StatusBadRequest)
+		}
+		proposalId, _ := proposalMap["proposalId"].(string)
+		txTag, _ := proposalMap["txTag"].(string)
+
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+		expiryDate, _ := time.Parse(time.RFC3339, fmt.Sprint(proposalMap["expiryDate"]))
+		if txTimestamp.AsTime().After(expiryDate) {
+			return nil, errors.NewCCError("proposal has expired", http.StatusBadRequest)
+		}
+
+		var args map[string]interface{}
+		argsJSON, _ := proposalMap["args"].(string)
+		nerr = json.Unmarshal([]byte(argsJSON), &args)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to decode proposal args")
+		}
+		policyTag := approvalPolicyTag(txTag, args)
+		policyMap, err := getApprovalPolicy(stub, policyTag)
+		if err != nil {
+			return nil, err
+		}
+		if policyMap == nil {
+			return nil, errors.NewCCError(fmt.Sprintf("transaction %s no longer has an approval policy", policyTag), http.StatusBadRequest)
+		}
+		threshold := toInt(policyMap["threshold"])
+
+		// Approvals are recorded once per organization
+		approvedBy, err := stub.GetMSPID()
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to get caller MSP")
+		}
+		if !contains(toStrings(policyMap["approvers"]), approvedBy) {
+			return nil, errors.NewCCError(fmt.Sprintf("%s is not an approver of %s", approvedBy, policyTag), http.StatusForbidden)
+		}
+		approvals := toStrings(proposalMap["approvals"])
+		if contains(approvals, approvedBy) {
+			return nil, errors.NewCCError(fmt.Sprintf("%s already approved this proposal", approvedBy), http.StatusConflict)
+		}
+		approvals = append(approvals, approvedBy)
+
+		updatedProposalMap, err := proposalKey.Update(stub, map[string]interface{}{
+			"approvals": approvals,
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to update proposal")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "proposalApprovedLog", proposalKey.Key(), fmt.Sprintf("Proposal for %s approved by %s (%d of %d)", txTag, approvedBy, len(approvals), threshold))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.ProposalApprovedPayload{
+			EventPayload: eventPayload,
+			ProposalID:   proposalId,
+			TxTag:        txTag,
+			Approvals:    approvals,
+			Threshold:    threshold,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
+		}
+
+		// The approval is logged before the execution so the event of the executed transaction prevails
+		events.CallEvent(stub, "proposalApprovedLog", logMsg)
+
+		if len(approvals) >= threshold {
+			return executeProposal(stub, proposalKey)
+		}
+
+		updatedProposalJSON, nerr := json.Marshal(updatedProposalMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return updatedProposalJSON, nil
+	},
+}
@@ -0,0 +1,98 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var ApprovePurchaseOrder = requireApproval(tx.Transaction{
+	Tag:         "approvePurchaseOrder",
+	Label:       "Approve Purchase Order",
+	Description: "Approve a pending purchase order so rations can be delivered against it",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{ // Only org2 admin can call this transaction
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "purchaseOrder",
+			Label:       "Purchase Order",
+			Description: "Purchase Order",
+			DataType:    "->purchaseOrder",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		purchaseOrderKey, ok := req["purchaseOrder"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter purchaseOrder must be an asset")
+		}
+
+		purchaseOrderMap, err := purchaseOrderKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get purchase order from the ledger", err.Status())
+		}
+		if purchaseOrderMap["status"] != string(datatypes.PurchaseOrderStatusPending) {
+			return nil, errors.NewCCError("only pending purchase orders can be approved", http.StatusBadRequest)
+		}
+		orderId, _ := purchaseOrderMap["orderId"].(string)
+
+		approvedBy, err := stub.GetMSPID()
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to get caller MSP")
+		}
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+
+		updatedPurchaseOrderMap, err := purchaseOrderKey.Update(stub, map[string]interface{}{
+			"status":       datatypes.PurchaseOrderStatusApproved,
+			"approvedBy":   approvedBy,
+			"approvalDate": txTimestamp.AsTime(),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to update purchase order")
+		}
+
+		updatedPurchaseOrderJSON, nerr := json.Marshal(updatedPurchaseOrderMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "purchaseOrderApprovedLog", purchaseOrderKey.Key(), fmt.Sprintf("Purchase order approved: %s", orderId))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.PurchaseOrderApprovedPayload{
+			EventPayload: eventPayload,
+			OrderID:      orderId,
+			ApprovedBy:   approvedBy,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
+		}
+
+		events.CallEvent(stub, "purchaseOrderApprovedLog", logMsg)
+
+		return updatedPurchaseOrderJSON, nil
+	},
+})
@@ -0,0 +1,160 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+)
+
+// setArchived archives the asset with the given reason, or restores it when archive is false.
+// The asset is rewritten from its ledger state, as Key.Update cannot remove the archive props.
+func setArchived(stub *sw.StubWrapper, key assets.Key, archive bool, reason string) ([]byte, errors.ICCError) {
+	assetMap, err := key.GetMap(stub)
+	if err != nil {
+		return nil, errors.WrapErrorWithStatus(err, fmt.Sprintf("failed to get %s from the ledger", key.TypeTag()), err.Status())
+	}
+
+	archived, _ := assetMap["archived"].(bool)
+	if archived == archive {
+		if archive {
+			return nil, errors.NewCCError(fmt.Sprintf("%s is already archived", key.TypeTag()), http.StatusConflict)
+		}
+		return nil, errors.NewCCError(fmt.Sprintf("%s is not archived", key.TypeTag()), http.StatusConflict)
+	}
+
+	eventTag := "assetRestoredLog"
+	message := fmt.Sprintf("Asset restored: %s", key.Key())
+	assetMap["archived"] = archive
+	delete(assetMap, "archiveReason")
+	delete(assetMap, "archivedBy")
+	delete(assetMap, "archivedDate")
+	if archive {
+		mspId, err := stub.GetMSPID()
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to get caller MSP")
+		}
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+
+		eventTag = "assetArchivedLog"
+		message = fmt.Sprintf("Asset archived: %s", key.Key())
+		assetMap["archiveReason"] = reason
+		assetMap["archivedBy"] = mspId
+		assetMap["archivedDate"] = txTimestamp.AsTime().Format(time.RFC3339)
+	}
+
+	asset, err := assets.NewAsset(assetMap)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to rebuild asset")
+	}
+	updatedMap, err := asset.Put(stub)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to update asset")
+	}
+
+	assetJSON, nerr := json.Marshal(updatedMap)
+	if nerr != nil {
+		return nil, errors.WrapError(nerr, "failed to marshal response")
+	}
+
+	// Marshal message to be logged
+	eventPayload, err := eventtypes.NewEventPayload(stub, eventTag, key.Key(), message)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to build event payload")
+	}
+	refs := []eventtypes.AssetRef{{AssetType: key.TypeTag(), Key: key.Key()}}
+	var logMsg []byte
+	if archive {
+		logMsg, nerr = json.Marshal(eventtypes.AssetArchivedPayload{
+			EventPayload: eventPayload,
+			Assets:       refs,
+			Reason:       reason,
+		})
+	} else {
+		logMsg, nerr = json.Marshal(eventtypes.AssetRestoredPayload{
+			EventPayload: eventPayload,
+			Assets:       refs,
+		})
+	}
+	if nerr != nil {
+		return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+	}
+
+	err = events.CallEvent(stub, eventTag, logMsg)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to emit event")
+	}
+
+	return assetJSON, nil
+}
+
+// excludeArchived restricts the selector of a CouchDB query on members or rations to the ones
+// which are not archived, unless the query already filters on the archived prop. Assets written
+// before archiving was introduced have no archived prop and are kept.
+func excludeArchived(query map[string]interface{}) {
+	selector, ok := query["selector"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	if assetType, _ := selector["@assetType"].(string); assetType != "member" && assetType != "ration" {
+		return
+	}
+	if _, ok := selector["archived"]; ok {
+		return
+	}
+
+	notArchived := []interface{}{
+		map[string]interface{}{"archived": map[string]interface{}{"$exists": false}},
+		map[string]interface{}{"archived": false},
+	}
+	if _, ok := selector["$or"]; !ok {
+		selector["$or"] = notArchived
+		return
+	}
+	and, _ := selector["$and"].([]interface{})
+	selector["$and"] = append(and, map[string]interface{}{"$or": notArchived})
+}
+
+// checkHardDelete refuses to delete members and rations referenced by distribution history,
+// which are kept on the ledger for audit and can only be archived
+func checkHardDelete(stub *sw.StubWrapper, key assets.Key, assetMap map[string]interface{}) errors.ICCError {
+	var refProp string
+	switch key.TypeTag() {
+	case "member":
+		refProp = "member.@key"
+	case "ration":
+		refProp = "ration.@key"
+	default:
+		return nil
+	}
+
+	query := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"@assetType": "rationSale",
+			refProp:      key.Key(),
+		},
+		"limit": 1,
+	}
+	response, err := assets.Search(stub, query, "", false)
+	if err != nil {
+		return errors.WrapErrorWithStatus(err, "error searching for distribution history", http.StatusInternalServerError)
+	}
+	if len(response.Result) > 0 {
+		return errors.NewCCError(fmt.Sprintf("%s is referenced by distribution history and can only be archived", key.TypeTag()), http.StatusConflict)
+	}
+
+	// Members served before rationSale recorded distributions only have their rationDistributionHistory
+	if key.TypeTag() == "member" && assetMap["rationDistributionHistory"] != nil {
+		return errors.NewCCError("member has distribution history and can only be archived", http.StatusConflict)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package txdefs
+
+import (
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// ArchiveMember recalls the ration card of a member by archiving the member, which requires
+// approval once the transaction has an approval policy
+var ArchiveMember = requireApproval(tx.Transaction{
+	Tag:         "archiveMember",
+	Label:       "Archive Member",
+	Description: "Archive a member, keeping it on the ledger for audit",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{ // Only org1 admin can call this transaction
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "member",
+			Label:       "Member",
+			Description: "Member to be archived",
+			DataType:    "->member",
+			Required:    true,
+		},
+		{
+			Tag:         "reason",
+			Label:       "Reason",
+			Description: "Why the member is archived",
+			DataType:    "string",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		memberKey, ok := req["member"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter member must be an asset")
+		}
+		reason, _ := req["reason"].(string)
+		if reason == "" {
+			return nil, errors.NewCCError("reason must be non-empty", http.StatusBadRequest)
+		}
+
+		return setArchived(stub, memberKey, true, reason)
+	},
+})
@@ -0,0 +1,56 @@
+package txdefs
+
+import (
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var ArchiveRation = tx.Transaction{
+	Tag:         "archiveRation",
+	Label:       "Archive Ration",
+	Description: "Archive a ration, keeping it on the ledger for audit",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{ // Only org2 admin can call this transaction
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "ration",
+			Label:       "Ration",
+			Description: "Ration to be archived",
+			DataType:    "->ration",
+			Required:    true,
+		},
+		{
+			Tag:         "reason",
+			Label:       "Reason",
+			Description: "Why the ration is archived",
+			DataType:    "string",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		rationKey, ok := req["ration"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter ration must be an asset")
+		}
+		reason, _ := req["reason"].(string)
+		if reason == "" {
+			return nil, errors.NewCCError("reason must be non-empty", http.StatusBadRequest)
+		}
+
+		return setArchived(stub, rationKey, true, reason)
+	},
+}
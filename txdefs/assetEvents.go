@@ -0,0 +1,298 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/assettypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// domainAssetTypes are the asset types whose changes through the generic
+// asset transactions are published on the CCAPI. Private assets are left out.
+var domainAssetTypes = map[string]bool{
+	"member":             true,
+	"distributor":        true,
+	"distributionPoint":  true,
+	"inventory":          true,
+	"ration":             true,
+	"restockRequest":     true,
+	"stock":              true,
+	"purchaseOrder":      true,
+	"delivery":           true,
+	"approvalPolicy":     true,
+	"proposal":           true,
+	"operatorAssignment": true,
+	"adminArea":          true,
+	"priceSchedule":      true,
+	"rationSale":         true,
+	"reimbursementClaim": true,
+	"paymentReceipt":     true,
+	"pickupBooking":      true,
+	"issuerKey":          true,
+	"cardToken":          true,
+	"offlineDevice":      true,
+	"offlineBatch":       true,
+	"offlineException":   true,
+	"nominee":            true,
+	"priorityClass":      true,
+}
+
+// CreateAsset is the cc-tools createAsset transaction emitting assetCreatedLog
+var CreateAsset = func() tx.Transaction {
+	t := tx.CreateAsset
+	routine := t.Routine
+	t.Routine = func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		assetList, _ := req["asset"].([]interface{})
+		for _, assetInterface := range assetList {
+			asset, ok := assetInterface.(assets.Asset)
+			if !ok {
+				continue
+			}
+			if permissions, ok := assettypes.PermissionMatrix[asset.TypeTag()]; ok {
+				err := checkAssetPermission(stub, permissions.Create, "create", asset.TypeTag())
+				if err != nil {
+					return nil, err
+				}
+				// Props reserved to other callers cannot be set on creation either
+				for _, prop := range asset.Type().Props {
+					value, set := asset[prop.Tag]
+					if !set || value == nil || prop.IsKey || isDefaultValue(prop, value) {
+						continue
+					}
+					err := checkAssetPermission(stub, permissions.PropCallers(prop), "set", fmt.Sprintf("%s of %s", prop.Tag, asset.TypeTag()))
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+			err := checkAssetAddresses(stub, asset.TypeTag(), asset)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		response, err := routine(stub, req)
+		if err != nil {
+			return nil, err
+		}
+
+		refs := []eventtypes.AssetRef{}
+		for _, assetInterface := range assetList {
+			asset, ok := assetInterface.(assets.Asset)
+			if ok && domainAssetTypes[asset.TypeTag()] {
+				refs = append(refs, eventtypes.AssetRef{AssetType: asset.TypeTag(), Key: asset.Key()})
+			}
+		}
+		if len(refs) == 0 {
+			return response, nil
+		}
+
+		eventPayload, err := eventtypes.NewEventPayload(stub, "assetCreatedLog", refs[0].Key, fmt.Sprintf("%d asset(s) created", len(refs)))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.AssetCreatedPayload{
+			EventPayload: eventPayload,
+			Assets:       refs,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		err = events.CallEvent(stub, "assetCreatedLog", logMsg)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to emit event")
+		}
+
+		return response, nil
+	}
+	return t
+}()
+
+// UpdateAsset is the cc-tools updateAsset transaction emitting assetUpdatedLog
+var UpdateAsset = func() tx.Transaction {
+	t := tx.UpdateAsset
+	routine := t.Routine
+	t.Routine = func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		request, _ := req["update"].(map[string]interface{})
+		key, keyErr := assets.NewKey(request)
+		if keyErr == nil {
+			if permissions, ok := assettypes.PermissionMatrix[key.TypeTag()]; ok {
+				for _, prop := range key.Type().Props {
+					if _, updated := request[prop.Tag]; !updated || prop.IsKey {
+						continue
+					}
+					err := checkAssetPermission(stub, permissions.PropCallers(prop), "update", fmt.Sprintf("%s of %s", prop.Tag, key.TypeTag()))
+					if err != nil {
+						return nil, err
+					}
+				}
+			}
+			err := checkAssetAddresses(stub, key.TypeTag(), request)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		response, err := routine(stub, req)
+		if err != nil {
+			return nil, err
+		}
+		if keyErr != nil || !domainAssetTypes[key.TypeTag()] {
+			return response, nil
+		}
+
+		updatedFields := []string{}
+		for field := range request {
+			if !strings.HasPrefix(field, "@") {
+				updatedFields = append(updatedFields, field)
+			}
+		}
+		// Map order differs between endorsers, which must produce the same payload
+		sort.Strings(updatedFields)
+
+		eventPayload, err := eventtypes.NewEventPayload(stub, "assetUpdatedLog", key.Key(), fmt.Sprintf("Asset updated: %s", key.Key()))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.AssetUpdatedPayload{
+			EventPayload:  eventPayload,
+			Assets:        []eventtypes.AssetRef{{AssetType: key.TypeTag(), Key: key.Key()}},
+			UpdatedFields: updatedFields,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		err = events.CallEvent(stub, "assetUpdatedLog", logMsg)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to emit event")
+		}
+
+		return response, nil
+	}
+	return t
+}()
+
+// DeleteAsset is the cc-tools deleteAsset transaction emitting assetDeletedLog,
+// or rationDeletedLog when the deleted asset is a ration
+var DeleteAsset = func() tx.Transaction {
+	t := tx.DeleteAsset
+	routine := t.Routine
+	t.Routine = func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		key, _ := req["key"].(assets.Key)
+
+		if permissions, ok := assettypes.PermissionMatrix[key.TypeTag()]; ok {
+			err := checkAssetPermission(stub, permissions.Delete, "delete", key.TypeTag())
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Read the asset before it is gone to report its content
+		var assetMap map[string]interface{}
+		if domainAssetTypes[key.TypeTag()] {
+			var err errors.ICCError
+			assetMap, err = key.GetMap(stub)
+			if err != nil {
+				return nil, errors.WrapErrorWithStatus(err, "failed to get asset from the ledger", err.Status())
+			}
+
+			err = checkHardDelete(stub, key, assetMap)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		response, err := routine(stub, req)
+		if err != nil {
+			return nil, err
+		}
+		if assetMap == nil {
+			return response, nil
+		}
+
+		var logMsg []byte
+		var nerr error
+		eventTag := "assetDeletedLog"
+		if key.TypeTag() == "ration" {
+			eventTag = "rationDeletedLog"
+			rationId, _ := assetMap["id"].(string)
+			category, amount, err := rationAmount(assetMap)
+			if err != nil {
+				return nil, err
+			}
+			eventPayload, err := eventtypes.NewEventPayload(stub, eventTag, key.Key(), fmt.Sprintf("Ration deleted: %s", rationId))
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to build event payload")
+			}
+			logMsg, nerr = json.Marshal(eventtypes.RationDeletedPayload{
+				EventPayload: eventPayload,
+				RationID:     rationId,
+				Quantity:     amount,
+				Unit:         category.BaseUnit(),
+			})
+		} else {
+			eventPayload, err := eventtypes.NewEventPayload(stub, eventTag, key.Key(), fmt.Sprintf("Asset deleted: %s", key.Key()))
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to build event payload")
+			}
+			logMsg, nerr = json.Marshal(eventtypes.AssetDeletedPayload{
+				EventPayload: eventPayload,
+				Assets:       []eventtypes.AssetRef{{AssetType: key.TypeTag(), Key: key.Key()}},
+			})
+		}
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		err = events.CallEvent(stub, eventTag, logMsg)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to emit event")
+		}
+
+		return response, nil
+	}
+	return t
+}()
+
+// isDefaultValue tells whether a prop holds its default value, which cc-tools sets on the
+// assets it creates
+func isDefaultValue(prop assets.AssetProp, value interface{}) bool {
+	if prop.DefaultValue == nil {
+		return false
+	}
+	dataType := assets.FetchDataType(prop.DataType)
+	if dataType == nil {
+		return false
+	}
+	_, defaultValue, err := dataType.Parse(prop.DefaultValue)
+	return err == nil && reflect.DeepEqual(value, defaultValue)
+}
+
+// checkAssetPermission refuses callers not listed for an action on a protected asset type
+func checkAssetPermission(stub *sw.StubWrapper, callers []accesscontrol.Caller, action, target string) errors.ICCError {
+	allowed := false
+	if len(callers) > 0 {
+		var err error
+		allowed, err = accesscontrol.AllowCaller(stub.Stub, callers)
+		if err != nil {
+			return errors.WrapError(err, "failed to check caller permissions")
+		}
+	}
+	if !allowed {
+		return errors.NewCCError(fmt.Sprintf("caller is not allowed to %s %s", action, target), http.StatusForbidden)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var AssignOperator = tx.Transaction{
+	Tag:         "assignOperator",
+	Label:       "Assign Operator",
+	Description: "Allow an operator to act on a distribution point",
+	Method:      "POST",
+	Callers: []accesscontrol.Caller{ // Only orgMSP admin can call this transaction
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "operatorMsp",
+			Label:       "Operator MSP",
+			Description: "MSP ID of the organization which issued the operator certificate",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "operatorId",
+			Label:       "Operator ID",
+			Description: "Operator ID, as found in the operatorId attribute of the operator certificate",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "distributionPoint",
+			Label:       "Distribution Point",
+			Description: "Distribution point the operator is assigned to",
+			DataType:    "->distributionPoint",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		operatorMsp, _ := req["operatorMsp"].(string)
+		operatorId, _ := req["operatorId"].(string)
+		distributionPointKey, ok := req["distributionPoint"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter distributionPoint must be an asset")
+		}
+
+		distributionPointMap, err := distributionPointKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get distribution point from the ledger", err.Status())
+		}
+		distributionPointId, _ := distributionPointMap["distributionPointId"].(string)
+
+		mspId, err := stub.GetMSPID()
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to get caller MSP")
+		}
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+
+		assignmentAsset, err := assets.NewAsset(map[string]interface{}{
+			"@assetType":        "operatorAssignment",
+			"operatorMsp":       operatorMsp,
+			"operatorId":        operatorId,
+			"distributionPoint": distributionPointKey,
+			"assignedBy":        mspId,
+			"assignedDate":      txTimestamp.AsTime().Format(time.RFC3339),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to create a new asset")
+		}
+
+		exists, err := assignmentAsset.ExistsInLedger(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to check operator assignment")
+		}
+		if exists {
+			return nil, errors.NewCCError(fmt.Sprintf("operator %s of %s is already assigned to distribution point %s", operatorId, operatorMsp, distributionPointId), http.StatusConflict)
+		}
+
+		assignmentMap, err := assignmentAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Error saving asset on blockchain")
+		}
+
+		assignmentJSON, nerr := json.Marshal(assignmentMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "operatorAssignedLog", assignmentAsset.Key(), fmt.Sprintf("Operator %s assigned to distribution point %s", operatorId, distributionPointId))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.OperatorAssignedPayload{
+			EventPayload:        eventPayload,
+			OperatorMSP:         operatorMsp,
+			OperatorID:          operatorId,
+			DistributionPointID: distributionPointId,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
+		}
+
+		events.CallEvent(stub, "operatorAssignedLog", logMsg)
+
+		return assignmentJSON, nil
+	},
+}
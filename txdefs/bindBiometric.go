@@ -0,0 +1,137 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// BindBiometric binds the fingerprint of a member, enrolled at an org1 office, for distribution
+// points to check with verifyBeneficiary. Only a hash of the template ID reaches the chaincode,
+// as a transient argument, and it is stored salted in the biometricBinding private collection.
+// Binding again replaces the fingerprint and lifts a lockout.
+var BindBiometric = tx.Transaction{
+	Tag:         "bindBiometric",
+	Label:       "Bind Biometric",
+	Description: "Bind the hash of the fingerprint template ID of a member",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{ // Only org1 admin can call this transaction
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "member",
+			Label:       "Member",
+			Description: "Member",
+			DataType:    "->member",
+			Required:    true,
+		},
+		{
+			Tag:         "templateHash",
+			Label:       "Template Hash",
+			Description: "Hex SHA-256 of the fingerprint template ID, computed by the reader",
+			DataType:    "string",
+			Required:    true,
+			Private:     true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		memberKey, ok := req["member"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter member must be an asset")
+		}
+		templateHash, _ := req["templateHash"].(string)
+		if err := checkTemplateHash(templateHash); err != nil {
+			return nil, err
+		}
+
+		memberMap, err := memberKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get member from the ledger", err.Status())
+		}
+		if archived, _ := memberMap["archived"].(bool); archived {
+			return nil, errors.NewCCError("member is archived", http.StatusConflict)
+		}
+		nid, _ := memberMap["nid"].(string)
+
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+
+		bindingKey, err := assets.NewKey(map[string]interface{}{
+			"@assetType": "biometricBinding",
+			"member":     memberKey,
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build biometric binding key")
+		}
+		rebound, err := bindingKey.ExistsInLedger(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to check biometric binding existence")
+		}
+
+		salt := stub.Stub.GetTxID()
+		bindingAsset, err := assets.NewAsset(map[string]interface{}{
+			"@assetType":     "biometricBinding",
+			"member":         memberKey,
+			"salt":           salt,
+			"templateHash":   saltedTemplateHash(salt, templateHash),
+			"failedAttempts": 0,
+			"locked":         false,
+			"boundDate":      txTimestamp.AsTime(),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build biometric binding")
+		}
+		_, err = bindingAsset.Put(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to record biometric binding")
+		}
+
+		updatedMemberMap, err := memberKey.Update(stub, map[string]interface{}{
+			"biometricBound": true,
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to update member")
+		}
+
+		updatedMemberJSON, nerr := json.Marshal(updatedMemberMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "biometricBoundLog", memberKey.Key(), fmt.Sprintf("Biometric bound to member %s", nid))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.BiometricBoundPayload{
+			EventPayload: eventPayload,
+			NID:          nid,
+			Rebound:      rebound,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "biometricBoundLog", logMsg)
+
+		return updatedMemberJSON, nil
+	},
+}
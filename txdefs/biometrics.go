@@ -0,0 +1,32 @@
+package txdefs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+// MaxBiometricAttempts is the number of failed verifications after which a biometric binding
+// is locked, until the member binds their fingerprint again
+const MaxBiometricAttempts = 3
+
+var templateHashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// checkTemplateHash checks the format of the hash of a fingerprint template ID, computed by
+// the reader so the template ID never leaves it
+func checkTemplateHash(templateHash string) errors.ICCError {
+	if !templateHashPattern.MatchString(templateHash) {
+		return errors.NewCCError("template hash must be a lowercase hex SHA-256", http.StatusBadRequest)
+	}
+	return nil
+}
+
+// saltedTemplateHash hashes a template hash with the salt of its binding, so the stored hash
+// cannot be matched against the hashes of other systems
+func saltedTemplateHash(salt, templateHash string) string {
+	hash := sha256.Sum256([]byte(salt + ":" + templateHash))
+	return hex.EncodeToString(hash[:])
+}
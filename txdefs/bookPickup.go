@@ -0,0 +1,178 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// BookPickup books the pickup of a member at a distribution point. The one-time code is then
+// issued to the member by org3 through issuePickupCode, so the operators never learn it. The
+// booking covers every ration collected on the visit, which completePickup closes.
+// Members of a priority class may book the slots reserved to them and, if their class allows
+// it, have the ration delivered at home.
+var BookPickup = tx.Transaction{
+	Tag:         "bookPickup",
+	Label:       "Book Pickup",
+	Description: "Book the pickup of a member at a distribution point",
+	Method:      "POST",
+	Callers: []accesscontrol.Caller{ // Only org1 admin can call this transaction
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "rationCardNumber",
+			Label:       "Ration Card Number",
+			Description: "Ration Card Number",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "distributionPoint",
+			Label:       "Distribution Point",
+			Description: "Distribution point the member picks the ration up at",
+			DataType:    "->distributionPoint",
+			Required:    true,
+		},
+		{
+			Tag:         "pickupDate",
+			Label:       "Pickup Date",
+			Description: "Pickup Date",
+			DataType:    "datetime",
+			Required:    true,
+		},
+		{
+			Tag:         "homeDelivery",
+			Label:       "Home Delivery",
+			Description: "Deliver the ration at the member's home, for priority classes allowing it",
+			DataType:    "boolean",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		rationCardNumber, _ := req["rationCardNumber"].(string)
+		pickupDate, _ := req["pickupDate"].(time.Time)
+		pickupDate = pickupDate.UTC() // Kept in UTC for the bookings of a day to be searched by date
+		homeDelivery, _ := req["homeDelivery"].(bool)
+		distributionPointKey, ok := req["distributionPoint"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter distributionPoint must be an asset")
+		}
+
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+		expiryDate := pickupDate.Add(pickupCodeValidity)
+		if !expiryDate.After(txTimestamp.AsTime()) {
+			return nil, errors.NewCCError("pickup date is over", http.StatusBadRequest)
+		}
+
+		// Find the member holding the ration card
+		query := map[string]interface{}{
+			"selector": map[string]interface{}{
+				"@assetType":       "member",
+				"rationCardNumber": rationCardNumber,
+			},
+		}
+		excludeArchived(query)
+		response, err := assets.Search(stub, query, "", false)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "error searching for ration card holder", 500)
+		}
+		if len(response.Result) == 0 {
+			return nil, errors.NewCCError("no member holds this ration card", http.StatusNotFound)
+		}
+		memberMap := response.Result[0]
+		if memberMap["rationCardStatus"] != string(datatypes.RationCardStatusActive) {
+			return nil, errors.NewCCError("ration card is not active", http.StatusForbidden)
+		}
+		memberKey, err := assets.NewKey(memberMap)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to get member key")
+		}
+
+		distributionPointMap, err := distributionPointKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get distribution point from the ledger", err.Status())
+		}
+		distributionPointId, _ := distributionPointMap["distributionPointId"].(string)
+
+		// Priority members may take reserved slots and ask for home delivery
+		classes, err := activePriorityClasses(stub)
+		if err != nil {
+			return nil, err
+		}
+		service := memberPriority(memberMap, classes, txTimestamp.AsTime())
+		if homeDelivery && !service.HomeDelivery {
+			return nil, errors.NewCCError("home delivery is reserved to priority classes allowing it", http.StatusForbidden)
+		}
+		if err := checkPickupSlot(stub, distributionPointMap, pickupDate, service, classes); err != nil {
+			return nil, err
+		}
+
+		bookingId := stub.Stub.GetTxID()
+		bookingAsset, err := assets.NewAsset(map[string]interface{}{
+			"@assetType":        "pickupBooking",
+			"bookingId":         bookingId,
+			"member":            memberKey,
+			"distributionPoint": distributionPointKey,
+			"pickupDate":        pickupDate,
+			"expiryDate":        expiryDate,
+			"priority":          service.eligible(),
+			"homeDelivery":      homeDelivery,
+			"status":            datatypes.PickupBookingStatusBooked,
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build pickup booking")
+		}
+		bookingMap, err := bookingAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to record pickup booking")
+		}
+
+		bookingJSON, nerr := json.Marshal(bookingMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "pickupBookedLog", bookingAsset.Key(), fmt.Sprintf("Pickup booked with card %s at distribution point %s", rationCardNumber, distributionPointId))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.PickupBookedPayload{
+			EventPayload:        eventPayload,
+			BookingID:           bookingId,
+			RationCardNumber:    rationCardNumber,
+			DistributionPointID: distributionPointId,
+			PickupDate:          pickupDate.Format(time.RFC3339),
+			ExpiryDate:          expiryDate.Format(time.RFC3339),
+			Priority:            service.eligible(),
+			HomeDelivery:        homeDelivery,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "pickupBookedLog", logMsg)
+
+		return bookingJSON, nil
+	},
+}
@@ -0,0 +1,204 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// MaxMemberBatchSize is the maximum number of members registered by a single bulkRegisterMembers call
+const MaxMemberBatchSize = 500
+
+// memberRegistrationFields are the member props which can be set at registration.
+// Ration card and archival props are left to their own transactions.
+var memberRegistrationFields = map[string]bool{
+	"nid":                true,
+	"name":               true,
+	"dateOfBirth":        true,
+	"height":             true,
+	"address":            true,
+	"contactInformation": true,
+	"familySize":         true,
+	"income":             true,
+	"disabilityStatus":   true,
+	"pregnant":           true,
+}
+
+// Member registration statuses
+const (
+	memberRowCreated   = "created"
+	memberRowDuplicate = "duplicate"
+	memberRowInvalid   = "invalid"
+)
+
+// memberRowResult is the outcome of the registration of a batch row
+type memberRowResult struct {
+	Row    int    `json:"row"` // Index of the record in the batch, starting at 1
+	NID    string `json:"nid,omitempty"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+	Key    string `json:"key,omitempty"`
+}
+
+var BulkRegisterMembers = tx.Transaction{
+	Tag:         "bulkRegisterMembers",
+	Label:       "Bulk Register Members",
+	Description: "Register a batch of members, skipping duplicate and invalid records",
+	Method:      "POST",
+	Callers: []accesscontrol.Caller{ // Only org1 admin can call this transaction
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "members",
+			Label:       "Members",
+			Description: fmt.Sprintf("Member records, at most %d", MaxMemberBatchSize),
+			DataType:    "[]@object",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		records, _ := req["members"].([]interface{})
+		if len(records) == 0 {
+			return nil, errors.NewCCError("members must not be empty", http.StatusBadRequest)
+		}
+		if len(records) > MaxMemberBatchSize {
+			return nil, errors.NewCCError(fmt.Sprintf("a batch holds at most %d members", MaxMemberBatchSize), http.StatusBadRequest)
+		}
+
+		results := []memberRowResult{}
+		created := []eventtypes.AssetRef{}
+		duplicates, invalid := 0, 0
+		seen := map[string]bool{}
+		for i, record := range records {
+			result := memberRowResult{Row: i + 1}
+			asset, reason := parseMemberRecord(record)
+			if asset == nil {
+				result.Status = memberRowInvalid
+				result.Reason = reason
+				invalid++
+				results = append(results, result)
+				continue
+			}
+			result.NID, _ = (*asset)["nid"].(string)
+			result.Key = asset.Key()
+
+			err := checkAssetAddresses(stub, "member", *asset)
+			if err != nil {
+				if err.Status() != http.StatusBadRequest {
+					return nil, err
+				}
+				result.Status = memberRowInvalid
+				result.Reason = err.Message()
+				invalid++
+				results = append(results, result)
+				continue
+			}
+
+			exists, err := asset.ExistsInLedger(stub)
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to check member existence")
+			}
+			if exists || seen[asset.Key()] {
+				result.Status = memberRowDuplicate
+				duplicates++
+				results = append(results, result)
+				continue
+			}
+
+			_, err = asset.PutNew(stub)
+			if err != nil {
+				return nil, errors.WrapErrorWithStatus(err, fmt.Sprintf("failed to register member of row %d", i+1), err.Status())
+			}
+			seen[asset.Key()] = true
+			result.Status = memberRowCreated
+			created = append(created, eventtypes.AssetRef{AssetType: "member", Key: asset.Key()})
+			results = append(results, result)
+		}
+
+		responseJSON, nerr := json.Marshal(map[string]interface{}{
+			"created":    len(created),
+			"duplicates": duplicates,
+			"invalid":    invalid,
+			"results":    results,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+		if len(created) == 0 {
+			return responseJSON, nil
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "membersRegisteredLog", created[0].Key, fmt.Sprintf("%d member(s) registered", len(created)))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.MembersRegisteredPayload{
+			EventPayload: eventPayload,
+			Created:      len(created),
+			Duplicates:   duplicates,
+			Invalid:      invalid,
+			Members:      created,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
+		}
+
+		events.CallEvent(stub, "membersRegisteredLog", logMsg)
+
+		return responseJSON, nil
+	},
+}
+
+// parseMemberRecord validates a member record of a batch with the member asset type and its
+// datatypes. It returns nil and the reason when the record is invalid.
+func parseMemberRecord(record interface{}) (*assets.Asset, string) {
+	recordMap, ok := record.(map[string]interface{})
+	if !ok {
+		return nil, "record must be a JSON object"
+	}
+
+	// Fields are checked in order for a row to always be rejected for the same reason
+	fields := make([]string, 0, len(recordMap))
+	for field := range recordMap {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	memberMap := map[string]interface{}{
+		"@assetType": "member",
+	}
+	for _, field := range fields {
+		if field == "@assetType" {
+			continue
+		}
+		if !memberRegistrationFields[field] {
+			return nil, fmt.Sprintf("field %s cannot be set at registration", field)
+		}
+		memberMap[field] = recordMap[field]
+	}
+
+	asset, err := assets.NewAsset(memberMap)
+	if err != nil {
+		return nil, err.Message()
+	}
+
+	return &asset, ""
+}
@@ -2,11 +2,15 @@ package txdefs
 
 import (
 	"encoding/json"
-	"sync"
+	"fmt"
+	"net/http"
 
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
 	"github.com/hyperledger-labs/cc-tools/accesscontrol"
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
 	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
 	tx "github.com/hyperledger-labs/cc-tools/transactions"
 )
@@ -14,7 +18,7 @@ import (
 var BuyRation = tx.Transaction{
 	Tag:         "buyRation",
 	Label:       "Buy Ration",
-	Description: "Customer buys a ration",
+	Description: "Member buys a ration at a distribution point",
 	Method:      "POST",
 	Callers: []accesscontrol.Caller{
 		{
@@ -35,86 +39,309 @@ var BuyRation = tx.Transaction{
 			Required:    true,
 		},
 		{
-			Tag:         "rationId",
-			Label:       "Ration ID",
-			Description: "Ration ID",
-			DataType:    "string",
+			Tag:         "ration",
+			Label:       "Ration",
+			Description: "Ration being bought",
+			DataType:    "->ration",
+			Required:    true,
+		},
+		{
+			Tag:         "distributionPoint",
+			Label:       "Distribution Point",
+			Description: "Distribution point handing out the ration",
+			DataType:    "->distributionPoint",
 			Required:    true,
 		},
 		{
 			Tag:         "quantity",
 			Label:       "Quantity",
-			Description: "Quantity of rations to buy",
-			DataType:    "integer",
+			Description: "Quantity to buy, in a unit of the ration category",
+			DataType:    "quantity",
+			Required:    true,
+		},
+		{
+			Tag:         "pickupBooking",
+			Label:       "Pickup Booking",
+			Description: "Booking of the pickup, whose code the member presented through verifyPickupCode",
+			DataType:    "->pickupBooking",
+			Required:    true,
+		},
+		{
+			Tag:         "pickupCode",
+			Label:       "Pickup Code",
+			Description: "One-time code of the booking presented by the member",
+			DataType:    "string",
 			Required:    true,
+			Private:     true,
+		},
+		{
+			Tag:         "collectorNid",
+			Label:       "Collector NID",
+			Description: "NID of the nominee collecting the ration, left empty if the member collects",
+			DataType:    "nid",
+		},
+		{
+			Tag:         "paymentReceipt",
+			Label:       "Payment Receipt",
+			Description: "Mobile wallet receipt of the payment, left empty for cash",
+			DataType:    "paymentReceipt",
 		},
 	},
 	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
-		customerKey, ok := req["customer"].(assets.Key)
+		rationCardNumber, _ := req["rationCardNumber"].(string)
+		quantity, _ := req["quantity"].(datatypes.Quantity)
+		collectorNid, _ := req["collectorNid"].(string)
+		receipt, paidByWallet := req["paymentReceipt"].(datatypes.PaymentReceipt)
+		rationKey, ok := req["ration"].(assets.Key)
 		if !ok {
-			return nil, errors.WrapError(nil, "Parameter customer must be an asset")
+			return nil, errors.WrapError(nil, "Parameter ration must be an asset")
 		}
-		newCustomerKey, ok := req["newCustomer"].(assets.Key)
+		distributionPointKey, ok := req["distributionPoint"].(assets.Key)
 		if !ok {
-			return nil, errors.WrapError(nil, "Parameter newCustomer must be an asset")
+			return nil, errors.WrapError(nil, "Parameter distributionPoint must be an asset")
 		}
+		bookingKey, ok := req["pickupBooking"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter pickupBooking must be an asset")
+		}
+		pickupCode, _ := req["pickupCode"].(string)
 
-		// Returns customer from channel
-		customerMap, err := customerKey.GetMap(stub)
+		// Find the member holding the ration card
+		query := map[string]interface{}{
+			"selector": map[string]interface{}{
+				"@assetType":       "member",
+				"rationCardNumber": rationCardNumber,
+			},
+		}
+		excludeArchived(query)
+		response, err := assets.Search(stub, query, "", false)
 		if err != nil {
-			return nil, errors.WrapErrorWithStatus(err, "failed to get asset from the ledger", err.Status())
+			return nil, errors.WrapErrorWithStatus(err, "error searching for ration card holder", 500)
+		}
+		if len(response.Result) == 0 {
+			return nil, errors.NewCCError("no member holds this ration card", http.StatusNotFound)
+		}
+		memberMap := response.Result[0]
+		if memberMap["rationCardStatus"] != string(datatypes.RationCardStatusActive) {
+			return nil, errors.NewCCError("ration card is not active", http.StatusForbidden)
+		}
+		nid, _ := memberMap["nid"].(string)
+		cardCategory, ok := memberMap["rationCardCategory"].(float64)
+		if !ok {
+			return nil, errors.NewCCError("ration card has no category to price the ration", http.StatusBadRequest)
 		}
 
-		// Returns new customer from channel
-		newCustomerMap, err := newCustomerKey.GetMap(stub)
+		// Returns ration and distribution point from channel
+		rationMap, err := rationKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get ration from the ledger", err.Status())
+		}
+		if archived, _ := rationMap["archived"].(bool); archived {
+			return nil, errors.NewCCError("ration is archived", http.StatusConflict)
+		}
+		category, rationQuantity, err := rationAmount(rationMap)
 		if err != nil {
-			return nil, errors.WrapErrorWithStatus(err, "failed to get asset from the ledger", err.Status())
+			return nil, err
 		}
+		amount, err := category.BaseAmount(quantity)
+		if err != nil {
+			return nil, err
+		}
+		if amount <= 0 {
+			return nil, errors.NewCCError("quantity must be greater than 0", http.StatusBadRequest)
+		}
+		rationId, _ := rationMap["id"].(string)
 
-		// Use a WaitGroup to wait for all updates to complete
-		var wg sync.WaitGroup
-		errChan := make(chan error, 1)
+		distributionPointMap, err := distributionPointKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get distribution point from the ledger", err.Status())
+		}
+		distributionPointId, _ := distributionPointMap["distributionPointId"].(string)
+		distributorRef, hasDistributor := distributionPointMap["distributor"].(map[string]interface{})
 
-		updateField := func(field string, value interface{}) {
-			defer wg.Done()
-			if value != nil {
-				customerMap[field] = value
-			}
+		// Only operators assigned to the distribution point can act on it
+		if err := checkOperatorAssignment(stub, distributionPointId); err != nil {
+			return nil, err
 		}
 
-		fields := map[string]interface{}{
-			"name":    newCustomerMap["name"],
-			"address": newCustomerMap["address"],
-			"phone":   newCustomerMap["phone"],
-			"email":   newCustomerMap["email"],
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
 		}
 
-		for field, value := range fields {
-			wg.Add(1)
-			go updateField(field, value)
+		// Priority members may collect more than the ration quantity
+		classes, err := activePriorityClasses(stub)
+		if err != nil {
+			return nil, err
+		}
+		if amount > memberPriority(memberMap, classes, txTimestamp.AsTime()).entitlement(rationQuantity) {
+			return nil, errors.NewCCError("requested quantity exceeds the entitlement of the member", http.StatusBadRequest)
 		}
 
-		wg.Wait()
-		close(errChan)
+		// The ration is collected by the member or one of their nominees
+		collectedBy, nomineeKey, err := collectorOf(stub, memberMap, collectorNid, txTimestamp.AsTime())
+		if err != nil {
+			return nil, err
+		}
 
-		for err := range errChan {
+		// The collector proves their presence with the code of a booking at this point
+		bookingMap, err := bookingKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get pickup booking from the ledger", err.Status())
+		}
+		bookingId, _ := bookingMap["bookingId"].(string)
+		if bookingMap["status"] != string(datatypes.PickupBookingStatusVerified) {
+			return nil, errors.NewCCError(fmt.Sprintf("pickup booking is %v, its code must be verified first", bookingMap["status"]), http.StatusForbidden)
+		}
+		if referenceKey(bookingMap["member"]) != memberMap["@key"] || referenceKey(bookingMap["distributionPoint"]) != distributionPointKey.Key() {
+			return nil, errors.NewCCError("pickup booking is for another member or distribution point", http.StatusForbidden)
+		}
+		if !scheduleDate(bookingMap["expiryDate"]).After(txTimestamp.AsTime()) {
+			return nil, errors.NewCCError("pickup booking has expired", http.StatusGone)
+		}
+		_, codeMap, err := getPickupCode(stub, bookingKey)
+		if err != nil {
+			return nil, err
+		}
+		if checkPickupCode(pickupCode) != nil || codeMap["codeHash"] != pickupCodeHash(bookingId, pickupCode) {
+			return nil, errors.NewCCError("pickup code does not match the booking", http.StatusForbidden)
+		}
+
+		// Take the rations out of the distribution point stock
+		stock, err := adjustStock(stub, distributionPointKey, distributionPointId, category, -amount)
+		if err != nil {
+			return nil, err
+		}
+
+		// Price the sale at the schedule in force for the card category
+		distribution := &sale{
+			ID:                  stub.Stub.GetTxID(),
+			Member:              memberMap,
+			Ration:              rationKey,
+			RationID:            rationId,
+			DistributionPoint:   distributionPointKey,
+			DistributionPointID: distributionPointId,
+			Category:            category,
+			Amount:              amount,
+			Date:                txTimestamp.AsTime(),
+			CollectedBy:         collectedBy,
+			Nominee:             nomineeKey,
+		}
+		if nomineeKey != nil {
+			distribution.NomineeNID = collectorNid
+		}
+		if hasDistributor {
+			distribution.Distributor = distributorRef
+		}
+		err = distribution.checkMonthlyPickup(stub)
+		if err != nil {
+			return nil, err
+		}
+		err = distribution.price(stub, datatypes.RationCardCategory(cardCategory))
+		if err != nil {
+			return nil, err
+		}
+		amountPaid, subsidyClaimed := distribution.AmountPaid, distribution.SubsidyClaimed
+
+		// A receipt settles a single sale, for the whole amount paid
+		var receiptKey assets.Key
+		if paidByWallet {
+			if receipt.Amount != amountPaid {
+				return nil, errors.NewCCError(fmt.Sprintf("receipt pays %s, the sale costs %s", receipt.Amount, amountPaid), http.StatusBadRequest)
+			}
+			receiptKey, err = assets.NewKey(map[string]interface{}{
+				"@assetType": "paymentReceipt",
+				"provider":   string(receipt.Provider),
+				"reference":  receipt.Reference,
+			})
 			if err != nil {
-				return nil, errors.WrapError(err, "failed to update customer asset")
+				return nil, errors.WrapError(err, "failed to build payment receipt key")
+			}
+			exists, err := receiptKey.ExistsInLedger(stub)
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to check payment receipt existence")
+			}
+			if exists {
+				return nil, errors.NewCCError(fmt.Sprintf("%s receipt %s already settled a sale", receipt.Provider, receipt.Reference), http.StatusConflict)
 			}
 		}
 
-		// Update customer in the ledger
-		updatedCustomerMap, err := customerKey.Update(stub, customerMap)
+		saleKey, saleMap, err := distribution.record(stub)
 		if err != nil {
-			return nil, errors.WrapError(err, "failed to update asset")
+			return nil, err
+		}
+		memberKey, err := assets.NewKey(memberMap)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to get member key")
+		}
+
+		// The booking covers every ration collected on the visit, until completePickup closes it
+		bookingSales, _ := bookingMap["sales"].([]interface{})
+		_, err = bookingKey.Update(stub, map[string]interface{}{
+			"sales": append(bookingSales, saleKey),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to update pickup booking")
 		}
 
-		// Marshal updated customer map to JSON
-		updatedCustomerJSON, nerr := json.Marshal(updatedCustomerMap)
+		if paidByWallet {
+			receiptAsset, err := assets.NewAsset(map[string]interface{}{
+				"@assetType":   "paymentReceipt",
+				"provider":     string(receipt.Provider),
+				"reference":    receipt.Reference,
+				"amount":       receipt.Amount,
+				"sale":         saleKey,
+				"member":       memberKey,
+				"recordedDate": distribution.Date,
+			})
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to build payment receipt")
+			}
+			_, err = receiptAsset.PutNew(stub)
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to record payment receipt")
+			}
+			saleMap, err = saleKey.Update(stub, map[string]interface{}{
+				"paymentReceipt": receiptKey,
+			})
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to update ration sale")
+			}
+		}
+
+		saleJSON, nerr := json.Marshal(saleMap)
 		if nerr != nil {
 			return nil, errors.WrapError(nerr, "failed to marshal response")
 		}
 
-		return updatedCustomerJSON, nil
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "rationPurchasedLog", memberKey.Key(), fmt.Sprintf("Ration %s purchased with card %s", rationId, rationCardNumber))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.RationPurchasedPayload{
+			EventPayload:        eventPayload,
+			RationCardNumber:    rationCardNumber,
+			NID:                 nid,
+			RationID:            rationId,
+			DistributionPointID: distributionPointId,
+			Quantity:            amount,
+			Unit:                category.BaseUnit(),
+			AmountPaid:          amountPaid,
+			SubsidyClaimed:      subsidyClaimed,
+			PaymentProvider:     receipt.Provider,
+			PaymentReference:    receipt.Reference,
+			BookingID:           bookingId,
+			CollectedBy:         collectedBy,
+			NomineeNID:          distribution.NomineeNID,
+			LowStockAlert:       stock.LowStockAlert,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
+		}
+
+		events.CallEvent(stub, "rationPurchasedLog", logMsg)
+
+		return saleJSON, nil
 	},
 }
@@ -0,0 +1,110 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// CompletePickup ends the visit of a member at the distribution point. Every ration category
+// collected on the visit is sold with the same verified booking, which is marked collected
+// once the member leaves.
+var CompletePickup = tx.Transaction{
+	Tag:         "completePickup",
+	Label:       "Complete Pickup",
+	Description: "Mark a pickup booking collected once the member has collected the rations of the visit",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{ // Only org1 admin can call this transaction
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "pickupBooking",
+			Label:       "Pickup Booking",
+			Description: "Pickup Booking",
+			DataType:    "->pickupBooking",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		bookingKey, ok := req["pickupBooking"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter pickupBooking must be an asset")
+		}
+
+		bookingMap, err := bookingKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get pickup booking from the ledger", err.Status())
+		}
+		bookingId, _ := bookingMap["bookingId"].(string)
+		if bookingMap["status"] != string(datatypes.PickupBookingStatusVerified) {
+			return nil, errors.NewCCError(fmt.Sprintf("pickup booking is %v", bookingMap["status"]), http.StatusConflict)
+		}
+		sales, _ := bookingMap["sales"].([]interface{})
+		if len(sales) == 0 {
+			return nil, errors.NewCCError("no ration was collected with the pickup booking", http.StatusBadRequest)
+		}
+
+		distributionPointKey, err := assets.NewKey(map[string]interface{}{"@assetType": "distributionPoint", "@key": referenceKey(bookingMap["distributionPoint"])})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build distribution point key")
+		}
+		distributionPointMap, err := distributionPointKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get distribution point from the ledger", err.Status())
+		}
+		distributionPointId, _ := distributionPointMap["distributionPointId"].(string)
+
+		// Only operators assigned to the distribution point can act on it
+		if err := checkOperatorAssignment(stub, distributionPointId); err != nil {
+			return nil, err
+		}
+
+		updatedBookingMap, err := bookingKey.Update(stub, map[string]interface{}{
+			"status": datatypes.PickupBookingStatusCollected,
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to update pickup booking")
+		}
+
+		bookingJSON, nerr := json.Marshal(updatedBookingMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "pickupCompletedLog", bookingKey.Key(), fmt.Sprintf("Pickup of booking %s completed at distribution point %s with %d sale(s)", bookingId, distributionPointId, len(sales)))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.PickupCompletedPayload{
+			EventPayload:        eventPayload,
+			BookingID:           bookingId,
+			DistributionPointID: distributionPointId,
+			Sales:               len(sales),
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "pickupCompletedLog", logMsg)
+
+		return bookingJSON, nil
+	},
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
 	"github.com/hyperledger-labs/cc-tools/accesscontrol"
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
@@ -51,6 +52,13 @@ var CreateDistributionPoint = tx.Transaction{
 			DataType:    "address",
 			Required:    false,
 		},
+		{
+			Tag:         "region",
+			Label:       "Region",
+			Description: "Administrative area the distribution point belongs to",
+			DataType:    "->adminArea",
+			Required:    false,
+		},
 		{
 			Tag:         "coordinates",
 			Label:       "GPS Location or Coordinates",
@@ -130,11 +138,21 @@ var CreateDistributionPoint = tx.Transaction{
 		numberOfCounters, _ := req["numberOfCounters"].(int)
 		inventory, _ := req["inventory"].(string)
 
+		if _, ok := req["address"]; ok {
+			err := checkAddress(stub, address)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		distributionPointMap := make(map[string]interface{})
 		distributionPointMap["@assetType"] = "distributionPoint"
 		distributionPointMap["distributionPointId"] = distributionPointId
 		distributionPointMap["name"] = name
 		distributionPointMap["address"] = address
+		if regionKey, ok := req["region"].(assets.Key); ok {
+			distributionPointMap["region"] = regionKey
+		}
 		distributionPointMap["coordinates"] = coordinates
 		distributionPointMap["contactInformation"] = contactInformation
 		// reference to the distributor object
@@ -171,7 +189,15 @@ var CreateDistributionPoint = tx.Transaction{
 			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
 		}
 
-		logMsg, ok := json.Marshal(fmt.Sprintf("New distribution point created: %s", distributionPointId))
+		eventPayload, err := eventtypes.NewEventPayload(stub, "distributionPointCreatedLog", distributionPointAsset.Key(), fmt.Sprintf("New distribution point created: %s", distributionPointId))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, ok := json.Marshal(eventtypes.DistributionPointCreatedPayload{
+			EventPayload:        eventPayload,
+			DistributionPointID: distributionPointId,
+			Capacity:            toInt(req["capacity"]),
+		})
 		if ok != nil {
 			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
 		}
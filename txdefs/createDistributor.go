@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
 	"github.com/hyperledger-labs/cc-tools/accesscontrol"
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
@@ -50,6 +52,13 @@ var CreateDistributor = tx.Transaction{
 			DataType:    "address",
 			Required:    false,
 		},
+		{
+			Tag:         "region",
+			Label:       "Region",
+			Description: "Administrative area the distributor belongs to",
+			DataType:    "->adminArea",
+			Required:    false,
+		},
 		{
 			Tag:         "contactInformation",
 			Label:       "Contact Information",
@@ -96,7 +105,7 @@ var CreateDistributor = tx.Transaction{
 	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
 		distributorId, _ := req["distributorId"].(string)
 		name, _ := req["name"].(string)
-		address, _ := req["address"].(string)
+		address, hasAddress := req["address"].(datatypes.Address) // This is a custom data type
 		contactInformation, _ := req["contactInformation"].(string)
 		licenseNumber, _ := req["licenseNumber"].(string)
 		licenseIssueDate, _ := req["licenseIssueDate"].(time.Time)
@@ -104,11 +113,23 @@ var CreateDistributor = tx.Transaction{
 		distributionArea, _ := req["distributionArea"].(string)
 		lastInspectionDate, _ := req["lastInspectionDate"].(time.Time)
 
+		if hasAddress {
+			err := checkAddress(stub, address)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		distributorMap := make(map[string]interface{})
 		distributorMap["@assetType"] = "distributor"
 		distributorMap["distributorId"] = distributorId
 		distributorMap["name"] = name
-		distributorMap["address"] = address
+		if hasAddress {
+			distributorMap["address"] = address
+		}
+		if regionKey, ok := req["region"].(assets.Key); ok {
+			distributorMap["region"] = regionKey
+		}
 		distributorMap["contactInformation"] = contactInformation
 		distributorMap["licenseNumber"] = licenseNumber
 		distributorMap["licenseIssueDate"] = licenseIssueDate
@@ -131,7 +152,15 @@ var CreateDistributor = tx.Transaction{
 			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
 		}
 
-		logMsg, ok := json.Marshal(fmt.Sprintf("New distributor created: %s", distributorId))
+		eventPayload, err := eventtypes.NewEventPayload(stub, "distributorCreatedLog", distributorAsset.Key(), fmt.Sprintf("New distributor created: %s", distributorId))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, ok := json.Marshal(eventtypes.DistributorCreatedPayload{
+			EventPayload:     eventPayload,
+			DistributorID:    distributorId,
+			DistributionArea: distributionArea,
+		})
 		if ok != nil {
 			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
 		}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
 	"github.com/hyperledger-labs/cc-tools/accesscontrol"
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
@@ -76,7 +77,15 @@ var CreateInventory = tx.Transaction{
 			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
 		}
 
-		logMsg, ok := json.Marshal(fmt.Sprintf("New inventory created: %s", name))
+		eventPayload, err := eventtypes.NewEventPayload(stub, "inventoryCreatedLog", inventoryAsset.Key(), fmt.Sprintf("New inventory created: %s", name))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, ok := json.Marshal(eventtypes.InventoryCreatedPayload{
+			EventPayload:  eventPayload,
+			InventoryName: name,
+			RationCount:   len(rations),
+		})
 		if ok != nil {
 			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
 		}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
 	"github.com/hyperledger-labs/cc-tools/accesscontrol"
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
@@ -64,7 +65,14 @@ var CreateNewLibrary = tx.Transaction{
 		}
 
 		// Marshall message to be logged
-		logMsg, ok := json.Marshal(fmt.Sprintf("New library name: %s", name))
+		eventPayload, err := eventtypes.NewEventPayload(stub, "createLibraryLog", libraryAsset.Key(), fmt.Sprintf("New library name: %s", name))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, ok := json.Marshal(eventtypes.LibraryCreatedPayload{
+			EventPayload: eventPayload,
+			Name:         name,
+		})
 		if ok != nil {
 			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
 		}
@@ -0,0 +1,169 @@
+package txdefs
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var CreateProposal = tx.Transaction{
+	Tag:         "createProposal",
+	Label:       "Create Proposal",
+	Description: "Propose a high-impact transaction for multi-signature approval",
+	Method:      "POST",
+	Callers: []accesscontrol.Caller{
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "org3MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "txTag",
+			Label:       "Transaction Tag",
+			Description: "Tag of the transaction to execute",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "args",
+			Label:       "Arguments",
+			Description: "Arguments of the transaction to execute",
+			DataType:    "@object",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		txTag, _ := req["txTag"].(string)
+
+		t, ok := multisigTxs[txTag]
+		if !ok {
+			return nil, errors.NewCCError(fmt.Sprintf("transaction %s cannot be proposed", txTag), http.StatusBadRequest)
+		}
+		args, _ := req["args"].(map[string]interface{})
+		policyTag := approvalPolicyTag(txTag, args)
+		policyMap, err := getApprovalPolicy(stub, policyTag)
+		if err != nil {
+			return nil, err
+		}
+		if policyMap == nil {
+			return nil, errors.NewCCError(fmt.Sprintf("transaction %s has no approval policy and can be called directly", policyTag), http.StatusBadRequest)
+		}
+
+		// The proposer must be an approver and be allowed to call the transaction
+		proposedBy, err := stub.GetMSPID()
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to get caller MSP")
+		}
+		if !contains(toStrings(policyMap["approvers"]), proposedBy) {
+			return nil, errors.NewCCError(fmt.Sprintf("%s is not an approver of %s", proposedBy, policyTag), http.StatusForbidden)
+		}
+		// Any approver of the current policy of a transaction may propose to change it
+		if _, ok := policyTargets[txTag]; !ok {
+			callPermission, nerr := accesscontrol.AllowCaller(stub.Stub, t.Callers)
+			if nerr != nil {
+				return nil, errors.WrapError(nerr, "failed to check permissions")
+			}
+			if !callPermission {
+				return nil, errors.NewCCError(fmt.Sprintf("current caller not allowed to call %s", txTag), http.StatusForbidden)
+			}
+		}
+
+		// Check the arguments now rather than when the proposal is executed
+		argsJSON, nerr := json.Marshal(req["args"])
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode proposal args")
+		}
+		_, err = t.GetArgs(proposalStub{ChaincodeStubInterface: stub.Stub, txTag: txTag, args: string(argsJSON)})
+		if err != nil {
+			return nil, errors.WrapError(err, "invalid proposal args")
+		}
+
+		creator, nerr := stub.Stub.GetCreator()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get caller identity")
+		}
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+		expiryDate := txTimestamp.AsTime().Add(time.Duration(toInt(policyMap["validityHours"])) * time.Hour)
+
+		proposalId := stub.Stub.GetTxID()
+		proposalAsset, err := assets.NewAsset(map[string]interface{}{
+			"@assetType":       "proposal",
+			"proposalId":       proposalId,
+			"txTag":            txTag,
+			"args":             string(argsJSON),
+			"proposedBy":       proposedBy,
+			"proposerIdentity": base64.StdEncoding.EncodeToString(creator),
+			"approvals":        []string{proposedBy},
+			"expiryDate":       expiryDate,
+			"status":           "open",
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to create a new asset")
+		}
+
+		proposalMap, err := proposalAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Error saving asset on blockchain")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "proposalCreatedLog", proposalAsset.Key(), fmt.Sprintf("New proposal created for %s", txTag))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.ProposalCreatedPayload{
+			EventPayload: eventPayload,
+			ProposalID:   proposalId,
+			TxTag:        txTag,
+			ProposedBy:   proposedBy,
+			ExpiryDate:   expiryDate.Format(time.RFC3339),
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
+		}
+
+		events.CallEvent(stub, "proposalCreatedLog", logMsg)
+
+		// A single approval may be enough, in which case the transaction response is returned
+		if toInt(policyMap["threshold"]) <= 1 {
+			proposalKey, err := assets.NewKey(proposalMap)
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to get proposal key")
+			}
+			return executeProposal(stub, proposalKey)
+		}
+
+		proposalJSON, nerr := json.Marshal(proposalMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return proposalJSON, nil
+	},
+}
@@ -0,0 +1,162 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var CreatePurchaseOrder = tx.Transaction{
+	Tag:         "createPurchaseOrder",
+	Label:       "Create Purchase Order",
+	Description: "Raise a purchase order of rations against a distributor",
+	Method:      "POST",
+	Callers: []accesscontrol.Caller{ // Only org2 admin can call this transaction
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "orderId",
+			Label:       "Order ID",
+			Description: "Order ID",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "distributor",
+			Label:       "Distributor",
+			Description: "Distributor supplying the rations",
+			DataType:    "->distributor",
+			Required:    true,
+		},
+		{
+			Tag:         "lineItems",
+			Label:       "Line Items",
+			Description: "Quantity ordered per ration category",
+			DataType:    "[]purchaseOrderLine",
+			Required:    true,
+		},
+		{
+			Tag:         "restockRequests",
+			Label:       "Restock Requests",
+			Description: "Open restock requests answered by this order",
+			DataType:    "[]->restockRequest",
+			Required:    false,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		orderId, _ := req["orderId"].(string)
+		distributorKey, ok := req["distributor"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter distributor must be an asset")
+		}
+		lineItems, _ := req["lineItems"].([]interface{})
+		restockRequests, _ := req["restockRequests"].([]interface{})
+
+		// Nothing is delivered yet, and each category is ordered once
+		lines := []datatypes.PurchaseOrderLine{}
+		categories := map[datatypes.RationCategory]bool{}
+		for _, lineItem := range lineItems {
+			line, _ := lineItem.(datatypes.PurchaseOrderLine)
+			if categories[line.Category] {
+				return nil, errors.NewCCError(fmt.Sprintf("category %s is ordered more than once", line.Category.Label()), http.StatusBadRequest)
+			}
+			categories[line.Category] = true
+			line.Allocated, line.Delivered = 0, 0
+			lines = append(lines, line)
+		}
+		if len(lines) == 0 {
+			return nil, errors.NewCCError("purchase order must have at least one line item", http.StatusBadRequest)
+		}
+
+		distributorMap, err := distributorKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get distributor from the ledger", err.Status())
+		}
+		distributorId, _ := distributorMap["distributorId"].(string)
+
+		// Mark the answered restock requests as ordered
+		for _, restockRequest := range restockRequests {
+			restockRequestKey, _ := restockRequest.(assets.Key)
+			restockRequestMap, err := restockRequestKey.GetMap(stub)
+			if err != nil {
+				return nil, errors.WrapErrorWithStatus(err, "failed to get restock request from the ledger", err.Status())
+			}
+			if restockRequestMap["status"] != "open" {
+				return nil, errors.NewCCError(fmt.Sprintf("restock request %s is not open", restockRequestMap["requestId"]), http.StatusBadRequest)
+			}
+			_, err = restockRequestKey.Update(stub, map[string]interface{}{
+				"status": "ordered",
+			})
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to update restock request")
+			}
+		}
+
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+
+		purchaseOrderMap := make(map[string]interface{})
+		purchaseOrderMap["@assetType"] = "purchaseOrder"
+		purchaseOrderMap["orderId"] = orderId
+		purchaseOrderMap["distributor"] = distributorKey
+		purchaseOrderMap["lineItems"] = lines
+		if len(restockRequests) > 0 {
+			purchaseOrderMap["restockRequests"] = restockRequests
+		}
+		purchaseOrderMap["status"] = datatypes.PurchaseOrderStatusPending
+		purchaseOrderMap["orderDate"] = txTimestamp.AsTime()
+
+		purchaseOrderAsset, err := assets.NewAsset(purchaseOrderMap)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to create a new asset")
+		}
+
+		_, err = purchaseOrderAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Error saving asset on blockchain")
+		}
+
+		purchaseOrderJSON, nerr := json.Marshal(purchaseOrderAsset)
+		if nerr != nil {
+			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "purchaseOrderCreatedLog", purchaseOrderAsset.Key(), fmt.Sprintf("New purchase order created: %s", orderId))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.PurchaseOrderCreatedPayload{
+			EventPayload:  eventPayload,
+			OrderID:       orderId,
+			DistributorID: distributorId,
+			LineItems:     lines,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
+		}
+
+		events.CallEvent(stub, "purchaseOrderCreatedLog", logMsg)
+
+		return purchaseOrderJSON, nil
+	},
+}
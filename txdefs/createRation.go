@@ -3,8 +3,12 @@ package txdefs
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
 	"github.com/hyperledger-labs/cc-tools/accesscontrol"
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
@@ -60,17 +64,17 @@ var CreateRation = tx.Transaction{
 			Required:    true,
 		},
 		{
-			Tag:         "distributedBy",
-			Label:       "Distributed By",
-			Description: "Distributed By",
-			DataType:    "->distributionPoint",
-			Required:    false,
+			Tag:         "purchaseOrder",
+			Label:       "Purchase Order",
+			Description: "Approved purchase order the ration is supplied against",
+			DataType:    "->purchaseOrder",
+			Required:    true,
 		},
 		{
 			Tag:         "quantity",
 			Label:       "Ration Quantity",
-			Description: "Ration Quantity",
-			DataType:    "integer",
+			Description: "Ration Quantity, in a unit of its category",
+			DataType:    "quantity",
 			Required:    true,
 		},
 		{
@@ -99,14 +103,54 @@ var CreateRation = tx.Transaction{
 	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
 		// Get the values from the request
 		id, _ := req["id"].(string)
-		category, _ := req["category"].(string)
+		category, _ := req["category"].(datatypes.RationCategory)
 		description, _ := req["description"].(string)
-		rationPackage, _ := req["package"].(string)
-		distributedBy, _ := req["distributedBy"].(assets.Key)
-		quantity, _ := req["quantity"].(int)
+		rationPackage, _ := req["package"].(datatypes.PackageType)
+		purchaseOrderKey, ok := req["purchaseOrder"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter purchaseOrder must be an asset")
+		}
+		quantity, _ := req["quantity"].(datatypes.Quantity)
 		expiryDate, _ := req["expiryDate"].(time.Time)
 		mfgDate, _ := req["mfgDate"].(time.Time)
-		batchNumber, _ := req["batchNumber"].(int)
+		batchNumber := toInt(req["batchNumber"])
+
+		amount, err := category.BaseAmount(quantity)
+		if err != nil {
+			return nil, err
+		}
+		if amount <= 0 {
+			return nil, errors.NewCCError("quantity must be greater than 0", http.StatusBadRequest)
+		}
+
+		// The ration must be supplied against an approved order for its category
+		purchaseOrderMap, err := purchaseOrderKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get purchase order from the ledger", err.Status())
+		}
+		status, _ := purchaseOrderMap["status"].(string)
+		if !datatypes.PurchaseOrderStatus(status).AcceptsDeliveries() {
+			return nil, errors.NewCCError("purchase order is not approved for deliveries", http.StatusBadRequest)
+		}
+		lines, err := getPurchaseOrderLines(purchaseOrderMap)
+		if err != nil {
+			return nil, err
+		}
+		// Each ration takes its quantity out of what is left to allocate on the order line
+		ordered := false
+		for i := range lines {
+			if lines[i].Category == category {
+				if float64(amount) > lines[i].Quantity-lines[i].Allocated {
+					return nil, errors.NewCCError(fmt.Sprintf("quantity exceeds the %s left to allocate on the purchase order", category.Label()), http.StatusBadRequest)
+				}
+				lines[i].Allocated += float64(amount)
+				ordered = true
+				break
+			}
+		}
+		if !ordered {
+			return nil, errors.NewCCError(fmt.Sprintf("category %s is not on the purchase order", category.Label()), http.StatusBadRequest)
+		}
 
 		// Check if the ration package is valid
 		rationMap := make(map[string]interface{})
@@ -114,21 +158,16 @@ var CreateRation = tx.Transaction{
 		rationMap["id"] = id
 		rationMap["category"] = category
 		rationMap["description"] = description
-		rationMap["package"] = rationPackage
+		rationMap["package"] = strings.ToLower(rationPackage.Label())
 
 		rationMap["quantity"] = quantity
 		rationMap["expiryDate"] = expiryDate
 		rationMap["mfgDate"] = mfgDate
 		rationMap["batchNumber"] = batchNumber
 
-		// get distributedBy asset
-		if distributedBy != nil {
-			d, err := distributedBy.Get(stub)
-			if err != nil {
-				return nil, errors.WrapError(err, "failed to get distributedBy asset from the ledger")
-			}
-			rationMap["distributedBy"] = d
-		}
+		// The ration is distributed by the supplier of the order
+		rationMap["purchaseOrder"] = purchaseOrderKey
+		rationMap["distributedBy"] = purchaseOrderMap["distributor"]
 
 		// Create a new ration asset
 		rationAsset, err := assets.NewAsset(rationMap)
@@ -141,6 +180,13 @@ var CreateRation = tx.Transaction{
 		if err != nil {
 			return nil, errors.WrapError(err, "Error saving asset on blockchain")
 		}
+		_, err = purchaseOrderKey.Update(stub, map[string]interface{}{
+			"lineItems": lines,
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to update purchase order")
+		}
+
 		// Marshal the asset back to JSON format
 		rationJSON, nerr := json.Marshal(rationAsset)
 		if nerr != nil {
@@ -148,8 +194,19 @@ var CreateRation = tx.Transaction{
 		}
 
 		// Log the event
-		logMsg, ok := json.Marshal(fmt.Sprintf("New ration created: %s", id))
-		if ok != nil {
+		eventPayload, err := eventtypes.NewEventPayload(stub, "rationCreatedLog", rationAsset.Key(), fmt.Sprintf("New ration created: %s", id))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.RationCreatedPayload{
+			EventPayload: eventPayload,
+			RationID:     id,
+			Category:     category.Label(),
+			Quantity:     amount,
+			Unit:         category.BaseUnit(),
+			BatchNumber:  batchNumber,
+		})
+		if nerr != nil {
 			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
 		}
 
@@ -0,0 +1,165 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// DecideReimbursementClaim approves a verified claim, rejecting some or all of its sales with
+// a reason. Accepted sales are paid the subsidy their price schedule gives rather than the one
+// they claim, and disputed sales found on verification must be rejected.
+var DecideReimbursementClaim = tx.Transaction{
+	Tag:         "decideReimbursementClaim",
+	Label:       "Decide Reimbursement Claim",
+	Description: "Approve a verified reimbursement claim, rejecting some of its sales with reasons",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{ // Only org2 admin can call this transaction
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "claim",
+			Label:       "Reimbursement Claim",
+			Description: "Reimbursement Claim",
+			DataType:    "->reimbursementClaim",
+			Required:    true,
+		},
+		{
+			Tag:         "rejections",
+			Label:       "Rejected Sales",
+			Description: "Sales of the claim not to pay, each with the reason why",
+			DataType:    "[]claimRejection",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		claimKey, ok := req["claim"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter claim must be an asset")
+		}
+
+		claimMap, err := getClaim(stub, claimKey, datatypes.ReimbursementClaimStatusVerified)
+		if err != nil {
+			return nil, err
+		}
+		month, _ := claimMap["month"].(string)
+		distributorId, err := claimDistributorId(stub, claimMap)
+		if err != nil {
+			return nil, err
+		}
+
+		// The ledger may have changed since verification, so the sales are checked again
+		sales, _, err := checkClaimSales(stub, claimMap)
+		if err != nil {
+			return nil, err
+		}
+
+		claimed := map[string]bool{}
+		saleRefs, _ := claimMap["sales"].([]interface{})
+		for _, saleRef := range saleRefs {
+			claimed[referenceKey(saleRef)] = true
+		}
+
+		rejected := map[string]bool{}
+		rejections := []interface{}{}
+		rejectionItems, _ := req["rejections"].([]interface{})
+		for _, rejectionItem := range rejectionItems {
+			rejection, _ := rejectionItem.(datatypes.ClaimRejection)
+			if !claimed[rejection.Sale] {
+				return nil, errors.NewCCError(fmt.Sprintf("sale %s is not part of the claim", rejection.Sale), http.StatusBadRequest)
+			}
+			if rejected[rejection.Sale] {
+				return nil, errors.NewCCError(fmt.Sprintf("sale %s is rejected more than once", rejection.Sale), http.StatusBadRequest)
+			}
+			rejected[rejection.Sale] = true
+			rejections = append(rejections, rejection)
+		}
+
+		amountApproved := datatypes.Money(0)
+		accepted := 0
+		for _, sale := range sales {
+			if rejected[sale.Key] {
+				continue
+			}
+			if sale.Disputed {
+				return nil, errors.NewCCError(fmt.Sprintf("sale %s is disputed and must be rejected", sale.Key), http.StatusBadRequest)
+			}
+			amountApproved += sale.Expected
+			accepted++
+		}
+
+		amountClaimed, err := datatypes.MoneyOf(claimMap["amountClaimed"])
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "invalid amount claimed", err.Status())
+		}
+		status := datatypes.ReimbursementClaimStatusPartiallyApproved
+		if accepted == 0 {
+			status = datatypes.ReimbursementClaimStatusRejected
+		} else if len(rejections) == 0 && amountApproved == amountClaimed {
+			status = datatypes.ReimbursementClaimStatusApproved
+		}
+
+		decidedBy, err := stub.GetMSPID()
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to get caller MSP")
+		}
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+
+		updatedClaimMap, err := claimKey.Update(stub, map[string]interface{}{
+			"status":         status,
+			"rejections":     rejections,
+			"amountApproved": amountApproved,
+			"decidedBy":      decidedBy,
+			"decisionDate":   txTimestamp.AsTime(),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to update reimbursement claim")
+		}
+
+		updatedClaimJSON, nerr := json.Marshal(updatedClaimMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "reimbursementClaimDecidedLog", claimKey.Key(), fmt.Sprintf("Claim of distributor %s for %s %s: %s of %s", distributorId, month, status, amountApproved, amountClaimed))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.ReimbursementClaimDecidedPayload{
+			EventPayload:   eventPayload,
+			DistributorID:  distributorId,
+			Month:          month,
+			Status:         status,
+			AmountClaimed:  amountClaimed,
+			AmountApproved: amountApproved,
+			RejectedSales:  len(rejections),
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "reimbursementClaimDecidedLog", logMsg)
+
+		return updatedClaimJSON, nil
+	},
+}
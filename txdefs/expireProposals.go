@@ -0,0 +1,106 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var ExpireProposals = tx.Transaction{
+	Tag:         "expireProposals",
+	Label:       "Expire Proposals",
+	Description: "Expire the open proposals past their expiry date",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "org3MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+		now := txTimestamp.AsTime()
+
+		query := map[string]interface{}{
+			"selector": map[string]interface{}{
+				"@assetType": "proposal",
+				"status":     "open",
+			},
+		}
+		response, err := assets.Search(stub, query, "", false)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "error searching for open proposals", 500)
+		}
+
+		proposalIds := []string{}
+		for _, proposalMap := range response.Result {
+			expiryDate, _ := time.Parse(time.RFC3339, fmt.Sprint(proposalMap["expiryDate"]))
+			if !now.After(expiryDate) {
+				continue
+			}
+
+			proposalKey, err := assets.NewKey(proposalMap)
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to get proposal key")
+			}
+			_, err = proposalKey.Update(stub, map[string]interface{}{
+				"status": "expired",
+			})
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to update proposal")
+			}
+
+			proposalId, _ := proposalMap["proposalId"].(string)
+			proposalIds = append(proposalIds, proposalId)
+		}
+
+		proposalIdsJSON, nerr := json.Marshal(proposalIds)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+		if len(proposalIds) == 0 {
+			return proposalIdsJSON, nil
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "proposalsExpiredLog", "", fmt.Sprintf("%d proposal(s) expired", len(proposalIds)))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.ProposalsExpiredPayload{
+			EventPayload: eventPayload,
+			ProposalIDs:  proposalIds,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
+		}
+
+		events.CallEvent(stub, "proposalsExpiredLog", logMsg)
+
+		return proposalIdsJSON, nil
+	},
+}
@@ -0,0 +1,119 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// adminAreaSearchTypes are the asset types which can be searched by administrative area
+var adminAreaSearchTypes = map[string]bool{
+	"member":            true,
+	"distributionPoint": true,
+}
+
+var GetAssetsByAdminArea = tx.Transaction{
+	Tag:         "getAssetsByAdminArea",
+	Label:       "Get Assets By Administrative Area",
+	Description: "List the members or distribution points whose address lies in a division, district, upazila or union",
+	Method:      "GET",
+	ReadOnly:    true,
+	Callers: []accesscontrol.Caller{
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "assetType",
+			Label:       "Asset Type",
+			Description: "Asset type to list, member or distributionPoint",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "adminArea",
+			Label:       "Administrative Area",
+			Description: "Area of any level the addresses must lie in",
+			DataType:    "->adminArea",
+			Required:    true,
+		},
+		{
+			Tag:         "includeArchived",
+			Label:       "Include Archived",
+			Description: "Include archived members in the results",
+			DataType:    "boolean",
+			Required:    false,
+		},
+		{
+			Tag:         "limit",
+			Label:       "Limit",
+			Description: "Number of assets per page, the results are not paginated when omitted",
+			DataType:    "integer",
+			Required:    false,
+		},
+		{
+			Tag:         "bookmark",
+			Label:       "Bookmark",
+			Description: "Bookmark of the page to return, as found in the metadata of the previous page",
+			DataType:    "string",
+			Required:    false,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		assetType, _ := req["assetType"].(string)
+		if !adminAreaSearchTypes[assetType] {
+			return nil, errors.NewCCError(fmt.Sprintf("asset type %s cannot be searched by administrative area", assetType), http.StatusBadRequest)
+		}
+		areaKey, ok := req["adminArea"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter adminArea must be an asset")
+		}
+
+		areaMap, err := areaKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get administrative area from the ledger", err.Status())
+		}
+		path, _ := areaMap["path"].(string)
+
+		query := map[string]interface{}{
+			"selector": adminAreaSelector(assetType, path),
+		}
+		if includeArchived, _ := req["includeArchived"].(bool); !includeArchived {
+			excludeArchived(query)
+		}
+		if _, ok := req["limit"]; ok {
+			query["limit"] = float64(toInt(req["limit"]))
+		}
+		if bookmark, ok := req["bookmark"].(string); ok {
+			query["bookmark"] = bookmark
+		}
+
+		response, err := assets.Search(stub, query, "", false)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to search assets by administrative area", err.Status())
+		}
+
+		responseJSON, nerr := json.Marshal(response)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return responseJSON, nil
+	},
+}
@@ -0,0 +1,125 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// stockLevelAsOf is the stock of a ration category at a past date
+type stockLevelAsOf struct {
+	Category     datatypes.RationCategory `json:"category"`
+	CategoryName string                   `json:"categoryName"`
+	Quantity     int                      `json:"quantity"`
+	MinimumLevel int                      `json:"minimumLevel"`
+	Unit         datatypes.Unit           `json:"unit"` // Base unit of the category
+	BelowMinimum bool                     `json:"belowMinimum"`
+	LastTx       string                   `json:"lastTx"` // Transaction which set this level
+}
+
+// stockSummaryAsOf is the stock of a distribution point at a past date
+type stockSummaryAsOf struct {
+	DistributionPointID string               `json:"distributionPointId"`
+	AsOf                string               `json:"asOf"`
+	Totals              []datatypes.Quantity `json:"totals"` // Total quantity per unit
+	Stocks              []stockLevelAsOf     `json:"stocks"`
+}
+
+var GetDistributionPointStockAsOf = tx.Transaction{
+	Tag:         "getDistributionPointStockAsOf",
+	Label:       "Get Distribution Point Stock As Of",
+	Description: "Rebuild the stock of a distribution point as it was at a given date",
+	Method:      "GET",
+	ReadOnly:    true,
+	Callers: []accesscontrol.Caller{
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "distributionPoint",
+			Label:       "Distribution Point",
+			Description: "Distribution Point",
+			DataType:    "->distributionPoint",
+			Required:    true,
+		},
+		{
+			Tag:         "asOf",
+			Label:       "As Of",
+			Description: "Date at which the stock is rebuilt",
+			DataType:    "datetime",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		distributionPointKey, ok := req["distributionPoint"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter distributionPoint must be an asset")
+		}
+		asOf, _ := req["asOf"].(time.Time)
+
+		distributionPointMap, err := distributionPointKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get distribution point from the ledger", err.Status())
+		}
+		distributionPointId, _ := distributionPointMap["distributionPointId"].(string)
+
+		summary := stockSummaryAsOf{
+			DistributionPointID: distributionPointId,
+			AsOf:                asOf.Format(time.RFC3339),
+			Stocks:              []stockLevelAsOf{},
+		}
+		totals := quantityTotals{}
+		for category := datatypes.RationCategoryGrains; category <= datatypes.RationCategoryOthers; category++ {
+			key, err := stockKey(distributionPointKey, category)
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to build stock key")
+			}
+			stockMap, err := assetAsOf(stub, key, asOf)
+			if err != nil {
+				return nil, err
+			}
+			if stockMap == nil {
+				continue
+			}
+
+			belowMinimum, _ := stockMap["belowMinimum"].(bool)
+			lastTx, _ := stockMap["@lastTx"].(string)
+			level := stockLevelAsOf{
+				Category:     category,
+				CategoryName: category.Label(),
+				Quantity:     toInt(stockMap["quantity"]),
+				MinimumLevel: toInt(stockMap["minimumLevel"]),
+				Unit:         category.BaseUnit(),
+				BelowMinimum: belowMinimum,
+				LastTx:       lastTx,
+			}
+			totals.add(category, level.Quantity)
+			summary.Stocks = append(summary.Stocks, level)
+		}
+		summary.Totals = totals.list()
+
+		summaryJSON, nerr := json.Marshal(summary)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return summaryJSON, nil
+	},
+}
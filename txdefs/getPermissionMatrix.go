@@ -0,0 +1,91 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/assettypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// propPermissions is the effective write permission of an asset prop
+type propPermissions struct {
+	Tag     string                 `json:"tag"`
+	IsKey   bool                   `json:"isKey"`
+	Writers []string               `json:"writers"`
+	Callers []accesscontrol.Caller `json:"callers,omitempty"` // Callers of the generic transactions, any when empty
+}
+
+// assetTypePermissions is the effective permission matrix entry of an asset type
+type assetTypePermissions struct {
+	AssetType string                 `json:"assetType"`
+	Protected bool                   `json:"protected"`
+	Create    []accesscontrol.Caller `json:"create,omitempty"`
+	Delete    []accesscontrol.Caller `json:"delete,omitempty"`
+	Props     []propPermissions      `json:"props"`
+}
+
+var GetPermissionMatrix = tx.Transaction{
+	Tag:         "getPermissionMatrix",
+	Label:       "Get Permission Matrix",
+	Description: "Dump who may create, update and delete each asset type, for auditing",
+	Method:      "GET",
+	ReadOnly:    true,
+	Callers: []accesscontrol.Caller{ // Any organization can call this transaction
+		{
+			MSP: `$org\d*MSP`,
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "assetType",
+			Label:       "Asset Type",
+			Description: "Restrict the dump to a single asset type",
+			DataType:    "string",
+			Required:    false,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		assetTypeFilter, _ := req["assetType"].(string)
+
+		matrix := []assetTypePermissions{}
+		for _, assetType := range assets.AssetTypeList() {
+			if assetTypeFilter != "" && assetType.Tag != assetTypeFilter {
+				continue
+			}
+
+			permissions, protected := assettypes.PermissionMatrix[assetType.Tag]
+			entry := assetTypePermissions{
+				AssetType: assetType.Tag,
+				Protected: protected,
+				Create:    permissions.Create,
+				Delete:    permissions.Delete,
+				Props:     []propPermissions{},
+			}
+			for _, prop := range assetType.Props {
+				entry.Props = append(entry.Props, propPermissions{
+					Tag:     prop.Tag,
+					IsKey:   prop.IsKey,
+					Writers: prop.Writers,
+					Callers: permissions.PropCallers(prop),
+				})
+			}
+			matrix = append(matrix, entry)
+		}
+		if assetTypeFilter != "" && len(matrix) == 0 {
+			return nil, errors.NewCCError(fmt.Sprintf("asset type %s does not exist", assetTypeFilter), http.StatusNotFound)
+		}
+
+		matrixJSON, nerr := json.Marshal(matrix)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return matrixJSON, nil
+	},
+}
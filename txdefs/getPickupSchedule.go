@@ -2,14 +2,21 @@ package txdefs
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
 
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
 	"github.com/hyperledger-labs/cc-tools/accesscontrol"
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
 	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
 	tx "github.com/hyperledger-labs/cc-tools/transactions"
 )
 
+// GetPickupSchedule reads the pickup schedule of a distribution point, or with asOf the one it
+// had at that date
 var GetPickupSchedule = tx.Transaction{
 	Tag:         "getPickupSchedule",
 	Label:       "Get Pickup Schedule",
@@ -33,23 +40,48 @@ var GetPickupSchedule = tx.Transaction{
 			DataType:    "string",
 			Required:    true,
 		},
+		{
+			Tag:         "asOf",
+			Label:       "As Of",
+			Description: "Read the pickup schedule the distribution point had at this date",
+			DataType:    "datetime",
+		},
 	},
 	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
 		distributionPointId, _ := req["distributionPointId"].(string)
 
-		// Retrieve the distribution point asset
-		distributionPointKey, ok := req[distributionPointId].(assets.Key)
-		if !ok {
-			return nil, errors.WrapError(nil, "failed to get distribution point ID from the request")
+		// Only operators assigned to the distribution point can act on it
+		if err := checkOperatorAssignment(stub, distributionPointId); err != nil {
+			return nil, err
 		}
 
-		distributionPointAsset, err := distributionPointKey.Get(stub)
+		// Retrieve the distribution point asset
+		distributionPointKey, err := assets.NewKey(map[string]interface{}{
+			"@assetType":          "distributionPoint",
+			"distributionPointId": distributionPointId,
+		})
 		if err != nil {
-			return nil, errors.WrapError(err, "failed to get distribution point asset from the ledger")
+			return nil, errors.WrapError(err, "failed to build distribution point key")
+		}
+
+		var distributionPointMap map[string]interface{}
+		if asOf, ok := req["asOf"].(time.Time); ok {
+			distributionPointMap, err = assetAsOf(stub, distributionPointKey, asOf)
+			if err != nil {
+				return nil, err
+			}
+			if distributionPointMap == nil {
+				return nil, errors.NewCCError(fmt.Sprintf("distribution point did not exist on %s", asOf.Format(time.RFC3339)), http.StatusNotFound)
+			}
+		} else {
+			distributionPointMap, err = distributionPointKey.GetMap(stub)
+			if err != nil {
+				return nil, errors.WrapErrorWithStatus(err, "failed to get distribution point asset from the ledger", err.Status())
+			}
 		}
 
 		// Get the pickup schedule from the distribution point asset
-		pickupSchedule, ok := distributionPointAsset.GetProp("pickupSchedule").(map[string]interface{})
+		pickupSchedule, ok := distributionPointMap["pickupSchedule"].(map[string]interface{})
 		if !ok {
 			return nil, errors.NewCCError("pickupSchedule property not found", 404)
 		}
@@ -57,9 +89,24 @@ var GetPickupSchedule = tx.Transaction{
 		// Marshal the pickup schedule back to JSON format
 		pickupScheduleJSON, nerr := json.Marshal(pickupSchedule)
 		if nerr != nil {
-			return nil, errors.WrapError(err, "failed to marshal response")
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "pickupScheduleGetLog", distributionPointKey.Key(), fmt.Sprintf("Pickup schedule retrieved for distribution point: %s", distributionPointId))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, erre := json.Marshal(eventtypes.PickupScheduleGetPayload{
+			EventPayload:        eventPayload,
+			DistributionPointID: distributionPointId,
+		})
+		if erre != nil {
+			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
 		}
 
+		events.CallEvent(stub, "pickupScheduleGetLog", logMsg)
+
 		return pickupScheduleJSON, nil
 	},
 }
@@ -0,0 +1,128 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// priorityMember is a member of a priority class, with the service they are given
+type priorityMember struct {
+	Member map[string]interface{} `json:"member"`
+	priority
+}
+
+// priorityMembersReport lists the priority members served by a distribution point
+type priorityMembersReport struct {
+	DistributionPointID string           `json:"distributionPointId"`
+	Area                string           `json:"area"`
+	Capacity            int              `json:"capacity,omitempty"`      // Pickups a day
+	ReservedSlots       int              `json:"reservedSlots,omitempty"` // Pickups a day reserved to priority members
+	Members             []priorityMember `json:"members"`
+}
+
+// GetPriorityMembers lists the members of priority classes a distribution point serves, those
+// whose address lies in its region (or in the area of its address, without a region)
+var GetPriorityMembers = tx.Transaction{
+	Tag:         "getPriorityMembers",
+	Label:       "Get Priority Members",
+	Description: "List the disabled, elderly and pregnant members served by a distribution point",
+	Method:      "GET",
+	ReadOnly:    true,
+	Callers: []accesscontrol.Caller{
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "distributionPoint",
+			Label:       "Distribution Point",
+			Description: "Distribution Point",
+			DataType:    "->distributionPoint",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		distributionPointKey, ok := req["distributionPoint"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter distributionPoint must be an asset")
+		}
+
+		distributionPointMap, err := distributionPointKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get distribution point from the ledger", err.Status())
+		}
+		distributionPointId, _ := distributionPointMap["distributionPointId"].(string)
+
+		path := addressPath(distributionPointMap["address"])
+		if region := referenceKey(distributionPointMap["region"]); region != "" {
+			regionKey, err := assets.NewKey(map[string]interface{}{"@assetType": "adminArea", "@key": region})
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to build region key")
+			}
+			regionMap, err := regionKey.GetMap(stub)
+			if err != nil {
+				return nil, errors.WrapErrorWithStatus(err, "failed to get region from the ledger", err.Status())
+			}
+			path, _ = regionMap["path"].(string)
+		}
+		if path == "" {
+			return nil, errors.NewCCError("distribution point has no region nor address to find its members", http.StatusBadRequest)
+		}
+
+		classes, err := activePriorityClasses(stub)
+		if err != nil {
+			return nil, err
+		}
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+
+		query := map[string]interface{}{
+			"selector": adminAreaSelector("member", path),
+		}
+		excludeArchived(query)
+		response, err := assets.Search(stub, query, "", false)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to search members", err.Status())
+		}
+
+		capacity := toInt(distributionPointMap["capacity"])
+		report := priorityMembersReport{
+			DistributionPointID: distributionPointId,
+			Area:                path,
+			Capacity:            capacity,
+			ReservedSlots:       reservedSlots(capacity, classes),
+			Members:             []priorityMember{},
+		}
+		for _, memberMap := range response.Result {
+			service := memberPriority(memberMap, classes, txTimestamp.AsTime())
+			if service.eligible() {
+				report.Members = append(report.Members, priorityMember{Member: memberMap, priority: service})
+			}
+		}
+
+		reportJSON, nerr := json.Marshal(report)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return reportJSON, nil
+	},
+}
@@ -44,6 +44,7 @@ var ReadTotalRationsByDistributionPoint = tx.Transaction{
 				"distributionPointId": distributionPointId,
 			},
 		}
+		excludeArchived(query)
 
 		var err error
 		response, err := assets.Search(stub, query, "", true)
@@ -0,0 +1,287 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// regionTotals are the aggregates of a region, rolled up from the areas it encloses
+type regionTotals struct {
+	Region              string               `json:"region"` // Path of the region
+	Beneficiaries       int                  `json:"beneficiaries"`
+	ActiveCards         int                  `json:"activeCards"`
+	DistributionPoints  int                  `json:"distributionPoints"`
+	Distributors        int                  `json:"distributors"`
+	StockQuantity       []datatypes.Quantity `json:"stockQuantity"`       // Quantity in stock per unit
+	DistributedQuantity []datatypes.Quantity `json:"distributedQuantity"` // Quantity distributed during the period, per unit
+
+	stock, distributed quantityTotals
+}
+
+// newRegionTotals returns empty totals for a region
+func newRegionTotals(region string) *regionTotals {
+	return &regionTotals{Region: region, stock: quantityTotals{}, distributed: quantityTotals{}}
+}
+
+// close lists the quantities summed by unit
+func (t *regionTotals) close() {
+	t.StockQuantity = t.stock.list()
+	t.DistributedQuantity = t.distributed.list()
+}
+
+// regionReport is the report of a region, along with the totals of the areas of the level below
+type regionReport struct {
+	regionTotals
+	Level     string         `json:"level"`
+	StartDate string         `json:"startDate,omitempty"`
+	EndDate   string         `json:"endDate,omitempty"`
+	Children  []regionTotals `json:"children"`
+}
+
+var GetRegionReport = tx.Transaction{
+	Tag:         "getRegionReport",
+	Label:       "Get Region Report",
+	Description: "Aggregate beneficiaries, active cards, stock and distributed quantity of a region and the areas it encloses",
+	Method:      "GET",
+	ReadOnly:    true,
+	Callers: []accesscontrol.Caller{
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "region",
+			Label:       "Region",
+			Description: "Division, district, upazila or union to report on",
+			DataType:    "->adminArea",
+			Required:    true,
+		},
+		{
+			Tag:         "startDate",
+			Label:       "Start Date",
+			Description: "Start of the period the distributed quantity is summed over",
+			DataType:    "datetime",
+			Required:    false,
+		},
+		{
+			Tag:         "endDate",
+			Label:       "End Date",
+			Description: "End of the period the distributed quantity is summed over",
+			DataType:    "datetime",
+			Required:    false,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		regionKey, ok := req["region"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter region must be an asset")
+		}
+		startDate, _ := req["startDate"].(time.Time)
+		endDate, _ := req["endDate"].(time.Time)
+
+		regionMap, err := regionKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get region from the ledger", err.Status())
+		}
+		path, _ := regionMap["path"].(string)
+		level := datatypes.AdminAreaLevel(toInt(regionMap["level"]))
+
+		report := regionReport{
+			regionTotals: *newRegionTotals(path),
+			Level:        level.AddressField(),
+			Children:     []regionTotals{},
+		}
+		if !startDate.IsZero() {
+			report.StartDate = startDate.Format(time.RFC3339)
+		}
+		if !endDate.IsZero() {
+			report.EndDate = endDate.Format(time.RFC3339)
+		}
+
+		// Areas enclosed by the region, which distribution points and distributors may reference
+		areas, err := assets.Search(stub, map[string]interface{}{
+			"selector": map[string]interface{}{
+				"@assetType": "adminArea",
+				"path":       map[string]interface{}{"$regex": "^" + regexp.QuoteMeta(path) + "(/|$)"},
+			},
+		}, "", false)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to search areas of the region", err.Status())
+		}
+		areaPaths := map[string]string{}
+		areaKeys := []interface{}{}
+		children := map[string]*regionTotals{}
+		for _, area := range areas.Result {
+			areaKey, _ := area["@key"].(string)
+			areaPath, _ := area["path"].(string)
+			areaPaths[areaKey] = areaPath
+			areaKeys = append(areaKeys, areaKey)
+			if datatypes.AdminAreaLevel(toInt(area["level"])) == level+1 {
+				children[areaPath] = newRegionTotals(areaPath)
+			}
+		}
+
+		// add applies an update to the region totals and to the totals of the child area enclosing areaPath
+		add := func(areaPath string, update func(*regionTotals)) {
+			update(&report.regionTotals)
+			if !strings.HasPrefix(areaPath, path+adminAreaPathSeparator) {
+				return
+			}
+			name := strings.SplitN(strings.TrimPrefix(areaPath, path+adminAreaPathSeparator), adminAreaPathSeparator, 2)[0]
+			if child, ok := children[path+adminAreaPathSeparator+name]; ok {
+				update(child)
+			}
+		}
+
+		// Beneficiaries are located by their address
+		memberQuery := map[string]interface{}{
+			"selector": adminAreaSelector("member", path),
+		}
+		excludeArchived(memberQuery)
+		members, err := assets.Search(stub, memberQuery, "", false)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to search members of the region", err.Status())
+		}
+		for _, member := range members.Result {
+			active := member["rationCardStatus"] == string(datatypes.RationCardStatusActive)
+			add(addressPath(member["address"]), func(t *regionTotals) {
+				t.Beneficiaries++
+				if active {
+					t.ActiveCards++
+				}
+			})
+		}
+
+		// Distribution points and distributors are located by the region they reference
+		distributors, err := assets.Search(stub, map[string]interface{}{
+			"selector": map[string]interface{}{
+				"@assetType":  "distributor",
+				"region.@key": map[string]interface{}{"$in": areaKeys},
+			},
+		}, "", false)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to search distributors of the region", err.Status())
+		}
+		for _, distributor := range distributors.Result {
+			add(areaPaths[referenceKey(distributor["region"])], func(t *regionTotals) {
+				t.Distributors++
+			})
+		}
+
+		distributionPoints, err := assets.Search(stub, map[string]interface{}{
+			"selector": map[string]interface{}{
+				"@assetType":  "distributionPoint",
+				"region.@key": map[string]interface{}{"$in": areaKeys},
+			},
+		}, "", false)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to search distribution points of the region", err.Status())
+		}
+		for _, distributionPoint := range distributionPoints.Result {
+			distributionPointKey, err := assets.NewKey(distributionPoint)
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to build distribution point key")
+			}
+			stockQuantity, distributedQuantity, err := distributionPointStock(stub, distributionPointKey, startDate, endDate)
+			if err != nil {
+				return nil, err
+			}
+			add(areaPaths[referenceKey(distributionPoint["region"])], func(t *regionTotals) {
+				t.DistributionPoints++
+				t.stock.merge(stockQuantity)
+				t.distributed.merge(distributedQuantity)
+			})
+		}
+
+		report.close()
+		for _, child := range children {
+			child.close()
+			report.Children = append(report.Children, *child)
+		}
+		sort.Slice(report.Children, func(i, j int) bool {
+			return report.Children[i].Region < report.Children[j].Region
+		})
+
+		reportJSON, nerr := json.Marshal(report)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return reportJSON, nil
+	},
+}
+
+// referenceKey returns the key of an asset reference read from the ledger
+func referenceKey(reference interface{}) string {
+	referenceMap, _ := reference.(map[string]interface{})
+	key, _ := referenceMap["@key"].(string)
+	return key
+}
+
+// distributionPointStock returns the current stock of a distribution point over every ration
+// category, and the quantity distributed between startDate and endDate (zero dates leave the
+// period open), summed from its ration sales. Both are summed by base unit.
+func distributionPointStock(stub *sw.StubWrapper, distributionPointKey assets.Key, startDate, endDate time.Time) (quantityTotals, quantityTotals, errors.ICCError) {
+	stockQuantity, distributedQuantity := quantityTotals{}, quantityTotals{}
+	for category := datatypes.RationCategoryGrains; category <= datatypes.RationCategoryOthers; category++ {
+		key, err := stockKey(distributionPointKey, category)
+		if err != nil {
+			return nil, nil, errors.WrapError(err, "failed to build stock key")
+		}
+		exists, err := key.ExistsInLedger(stub)
+		if err != nil {
+			return nil, nil, errors.WrapError(err, "failed to check stock existence")
+		}
+		if !exists {
+			continue
+		}
+		stockMap, err := key.GetMap(stub)
+		if err != nil {
+			return nil, nil, errors.WrapErrorWithStatus(err, "failed to get stock from the ledger", err.Status())
+		}
+		stockQuantity.add(category, toInt(stockMap["quantity"]))
+	}
+
+	// Sales are recorded by buyRation and by the replay of offline batches alike
+	selector := map[string]interface{}{
+		"@assetType":             "rationSale",
+		"distributionPoint.@key": distributionPointKey.Key(),
+	}
+	if !startDate.IsZero() || !endDate.IsZero() {
+		// The end of the period is included, up to the second
+		if !endDate.IsZero() {
+			endDate = endDate.Add(time.Second)
+		}
+		selector["saleDate"] = dateRange(startDate, endDate)
+	}
+	sales, err := assets.Search(stub, map[string]interface{}{"selector": selector}, "", false)
+	if err != nil {
+		return nil, nil, errors.WrapErrorWithStatus(err, "failed to search ration sales", err.Status())
+	}
+	for _, saleMap := range sales.Result {
+		category, _ := saleMap["category"].(float64)
+		distributedQuantity.add(datatypes.RationCategory(category), toInt(saleMap["quantity"]))
+	}
+
+	return stockQuantity, distributedQuantity, nil
+}
@@ -0,0 +1,148 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// subsidyLiability is the subsidy owed to a distributor for the sales of a month
+type subsidyLiability struct {
+	Distributor    string          `json:"distributor,omitempty"` // Key of the distributor, empty for distribution points without one
+	DistributorID  string          `json:"distributorId,omitempty"`
+	Month          string          `json:"month"`
+	Sales          int             `json:"sales"`
+	AmountPaid     datatypes.Money `json:"amountPaid"`
+	SubsidyClaimed datatypes.Money `json:"subsidyClaimed"`
+}
+
+// subsidyLiabilityReport lists the liabilities, along with their totals
+type subsidyLiabilityReport struct {
+	Liabilities    []subsidyLiability `json:"liabilities"`
+	AmountPaid     datatypes.Money    `json:"amountPaid"`
+	SubsidyClaimed datatypes.Money    `json:"subsidyClaimed"`
+}
+
+var GetSubsidyLiability = tx.Transaction{
+	Tag:         "getSubsidyLiability",
+	Label:       "Get Subsidy Liability",
+	Description: "Sum the subsidy claimed on ration sales per distributor per month, for reimbursement",
+	Method:      "GET",
+	ReadOnly:    true,
+	Callers: []accesscontrol.Caller{
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "month",
+			Label:       "Month",
+			Description: "Month of the sales (YYYY-MM), all months when omitted",
+			DataType:    "string",
+			Required:    false,
+		},
+		{
+			Tag:         "distributor",
+			Label:       "Distributor",
+			Description: "Distributor to report on, all distributors when omitted",
+			DataType:    "->distributor",
+			Required:    false,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		selector := map[string]interface{}{
+			"@assetType": "rationSale",
+		}
+		if month, ok := req["month"].(string); ok && month != "" {
+			if _, nerr := time.Parse(saleMonthLayout, month); nerr != nil {
+				return nil, errors.NewCCError("month must be formatted as YYYY-MM", http.StatusBadRequest)
+			}
+			selector["month"] = month
+		}
+		if distributorKey, ok := req["distributor"].(assets.Key); ok {
+			selector["distributor.@key"] = distributorKey.Key()
+		}
+
+		sales, err := assets.Search(stub, map[string]interface{}{"selector": selector}, "", false)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to search ration sales", err.Status())
+		}
+
+		report := subsidyLiabilityReport{Liabilities: []subsidyLiability{}}
+		liabilities := map[[2]string]*subsidyLiability{}
+		for _, sale := range sales.Result {
+			distributor := referenceKey(sale["distributor"])
+			month, _ := sale["month"].(string)
+			amountPaid, err := datatypes.MoneyOf(sale["amountPaid"])
+			if err != nil {
+				return nil, errors.WrapErrorWithStatus(err, "invalid amount paid", err.Status())
+			}
+			subsidyClaimed, err := datatypes.MoneyOf(sale["subsidyClaimed"])
+			if err != nil {
+				return nil, errors.WrapErrorWithStatus(err, "invalid subsidy claimed", err.Status())
+			}
+
+			liability, ok := liabilities[[2]string{distributor, month}]
+			if !ok {
+				liability = &subsidyLiability{Distributor: distributor, Month: month}
+				liabilities[[2]string{distributor, month}] = liability
+			}
+			liability.Sales++
+			liability.AmountPaid += amountPaid
+			liability.SubsidyClaimed += subsidyClaimed
+			report.AmountPaid += amountPaid
+			report.SubsidyClaimed += subsidyClaimed
+		}
+
+		distributorIds := map[string]string{}
+		for _, liability := range liabilities {
+			if liability.Distributor != "" {
+				if _, ok := distributorIds[liability.Distributor]; !ok {
+					distributorKey, err := assets.NewKey(map[string]interface{}{"@assetType": "distributor", "@key": liability.Distributor})
+					if err != nil {
+						return nil, errors.WrapError(err, "failed to build distributor key")
+					}
+					distributorMap, err := distributorKey.GetMap(stub)
+					if err != nil {
+						return nil, errors.WrapErrorWithStatus(err, "failed to get distributor from the ledger", err.Status())
+					}
+					distributorIds[liability.Distributor], _ = distributorMap["distributorId"].(string)
+				}
+				liability.DistributorID = distributorIds[liability.Distributor]
+			}
+			report.Liabilities = append(report.Liabilities, *liability)
+		}
+		sort.Slice(report.Liabilities, func(i, j int) bool {
+			a, b := report.Liabilities[i], report.Liabilities[j]
+			if a.Month != b.Month {
+				return a.Month < b.Month
+			}
+			return a.DistributorID < b.DistributorID
+		})
+
+		reportJSON, nerr := json.Marshal(report)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return reportJSON, nil
+	},
+}
@@ -0,0 +1,59 @@
+package txdefs
+
+import "time"
+
+// toInt converts a numeric value read from a request or from the ledger to int.
+// Integer args are parsed as int64 by cc-tools while values read back from the
+// ledger are float64, so both (and int) are accepted.
+func toInt(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
+
+// toStrings converts a string list read from a request or from the ledger to []string
+func toStrings(value interface{}) []string {
+	strs := []string{}
+	list, _ := value.([]interface{})
+	for _, item := range list {
+		if str, ok := item.(string); ok {
+			strs = append(strs, str)
+		}
+	}
+	return strs
+}
+
+// contains checks if a string list holds the given value
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ledgerDateLayout formats the bounds of a range of datetime props in CouchDB selectors.
+// Datetimes are stored as RFC3339 strings in UTC, so they compare as strings once the zone
+// and fractional seconds are left out of the bounds.
+const ledgerDateLayout = "2006-01-02T15:04:05"
+
+// dateRange returns the CouchDB condition matching the datetime props from start, included,
+// up to end, excluded. A zero bound leaves its side of the range open.
+func dateRange(start, end time.Time) map[string]interface{} {
+	condition := map[string]interface{}{}
+	if !start.IsZero() {
+		condition["$gte"] = start.UTC().Format(ledgerDateLayout)
+	}
+	if !end.IsZero() {
+		condition["$lt"] = end.UTC().Format(ledgerDateLayout)
+	}
+	return condition
+}
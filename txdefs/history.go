@@ -0,0 +1,259 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+const (
+	defaultHistoryPageSize = 20
+	maxHistoryPageSize     = 100
+)
+
+// fieldChange is the change of a single field between two versions of an asset
+type fieldChange struct {
+	Field string      `json:"field"`
+	Old   interface{} `json:"old,omitempty"`
+	New   interface{} `json:"new,omitempty"`
+}
+
+// historyVersion is a version of an asset, as written by a transaction
+type historyVersion struct {
+	TxID      string                 `json:"txId"`
+	Timestamp string                 `json:"timestamp"`
+	MSP       string                 `json:"msp,omitempty"` // MSP of the invoker, unknown for deletions
+	IsDelete  bool                   `json:"isDelete"`
+	Value     map[string]interface{} `json:"value,omitempty"`
+	Diff      []fieldChange          `json:"diff"` // Changes against the previous version
+}
+
+// historyPage is a page of the versions of an asset matching the time range, oldest first
+type historyPage struct {
+	Key      string           `json:"key"`
+	Versions []historyVersion `json:"versions"`
+	Total    int              `json:"total"`
+	Page     int              `json:"page"`
+	PageSize int              `json:"pageSize"`
+}
+
+// historyArgs builds the arguments of an asset history transaction
+func historyArgs(assetType, label string) []tx.Argument {
+	return []tx.Argument{
+		{
+			Tag:         assetType,
+			Label:       label,
+			Description: fmt.Sprintf("%s whose history is read", label),
+			DataType:    "->" + assetType,
+			Required:    true,
+		},
+		{
+			Tag:         "startDate",
+			Label:       "Start Date",
+			Description: "Only return versions written from this date on",
+			DataType:    "datetime",
+			Required:    false,
+		},
+		{
+			Tag:         "endDate",
+			Label:       "End Date",
+			Description: "Only return versions written up to this date",
+			DataType:    "datetime",
+			Required:    false,
+		},
+		{
+			Tag:         "page",
+			Label:       "Page",
+			Description: "Page of versions to return, starting at 1",
+			DataType:    "integer",
+			Required:    false,
+		},
+		{
+			Tag:         "pageSize",
+			Label:       "Page Size",
+			Description: fmt.Sprintf("Number of versions per page, defaults to %d", defaultHistoryPageSize),
+			DataType:    "integer",
+			Required:    false,
+		},
+	}
+}
+
+// readHistory returns the versions of the asset given in the assetType argument, with the
+// field-level diff of each version against the previous one, filtered and paginated
+func readHistory(stub *sw.StubWrapper, req map[string]interface{}, assetType string) ([]byte, errors.ICCError) {
+	key, ok := req[assetType].(assets.Key)
+	if !ok {
+		return nil, errors.WrapError(nil, fmt.Sprintf("Parameter %s must be an asset", assetType))
+	}
+	startDate, _ := req["startDate"].(time.Time)
+	endDate, _ := req["endDate"].(time.Time)
+
+	page := 1
+	if _, ok := req["page"]; ok {
+		page = toInt(req["page"])
+	}
+	pageSize := defaultHistoryPageSize
+	if _, ok := req["pageSize"]; ok {
+		pageSize = toInt(req["pageSize"])
+	}
+	if page < 1 {
+		return nil, errors.NewCCError("page must be greater than 0", http.StatusBadRequest)
+	}
+	if pageSize < 1 || pageSize > maxHistoryPageSize {
+		return nil, errors.NewCCError(fmt.Sprintf("page size must be between 1 and %d", maxHistoryPageSize), http.StatusBadRequest)
+	}
+
+	history, err := keyHistory(stub, key)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []historyVersion{}
+	var previous map[string]interface{}
+	for _, version := range history {
+		diff := diffVersions(previous, version.Value)
+		previous = version.Value
+
+		if (!startDate.IsZero() && version.Timestamp.Before(startDate)) || (!endDate.IsZero() && version.Timestamp.After(endDate)) {
+			continue
+		}
+
+		msp, _ := version.Value["@lastTouchBy"].(string)
+		versions = append(versions, historyVersion{
+			TxID:      version.TxID,
+			Timestamp: version.Timestamp.Format(time.RFC3339),
+			MSP:       msp,
+			IsDelete:  version.IsDelete,
+			Value:     version.Value,
+			Diff:      diff,
+		})
+	}
+	if len(versions) == 0 && startDate.IsZero() && endDate.IsZero() {
+		return nil, errors.NewCCError("history not found", http.StatusNotFound)
+	}
+
+	response := historyPage{
+		Key:      key.Key(),
+		Versions: []historyVersion{},
+		Total:    len(versions),
+		Page:     page,
+		PageSize: pageSize,
+	}
+	first := (page - 1) * pageSize
+	if first < len(versions) {
+		last := first + pageSize
+		if last > len(versions) {
+			last = len(versions)
+		}
+		response.Versions = versions[first:last]
+	}
+
+	responseJSON, nerr := json.Marshal(response)
+	if nerr != nil {
+		return nil, errors.WrapError(nerr, "failed to marshal response")
+	}
+
+	return responseJSON, nil
+}
+
+// diffVersions lists the fields changed between two versions of an asset, leaving out the
+// metadata fields. A nil version stands for an asset which does not exist.
+func diffVersions(previous, current map[string]interface{}) []fieldChange {
+	fields := map[string]bool{}
+	for field := range previous {
+		fields[field] = true
+	}
+	for field := range current {
+		fields[field] = true
+	}
+
+	diff := []fieldChange{}
+	for field := range fields {
+		if strings.HasPrefix(field, "@") {
+			continue
+		}
+		oldValue, newValue := previous[field], current[field]
+		if !reflect.DeepEqual(oldValue, newValue) {
+			diff = append(diff, fieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+	sort.Slice(diff, func(i, j int) bool {
+		return diff[i].Field < diff[j].Field
+	})
+
+	return diff
+}
+
+// keyVersion is a version of an asset read from its key history
+type keyVersion struct {
+	TxID      string
+	Timestamp time.Time
+	IsDelete  bool
+	Value     map[string]interface{} // nil for deletions
+}
+
+// keyHistory reads every version of an asset, oldest first
+func keyHistory(stub *sw.StubWrapper, key assets.Key) ([]keyVersion, errors.ICCError) {
+	historyIterator, nerr := stub.Stub.GetHistoryForKey(key.Key())
+	if nerr != nil {
+		return nil, errors.WrapError(nerr, "failed to read asset history from blockchain")
+	}
+	defer historyIterator.Close()
+
+	history := []keyVersion{}
+	for historyIterator.HasNext() {
+		queryResponse, nerr := historyIterator.Next()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "error iterating asset history")
+		}
+
+		version := keyVersion{
+			TxID:      queryResponse.TxId,
+			Timestamp: queryResponse.Timestamp.AsTime(),
+			IsDelete:  queryResponse.IsDelete,
+		}
+		if !queryResponse.IsDelete {
+			nerr = json.Unmarshal(queryResponse.Value, &version.Value)
+			if nerr != nil {
+				return nil, errors.WrapError(nerr, "failed to unmarshal asset version")
+			}
+		}
+		history = append(history, version)
+	}
+
+	// The peer returns the versions newest first, in block and transaction order. Timestamps are
+	// set by the clients and are not used to order them.
+	for i, j := 0, len(history)-1; i < j; i, j = i+1, j-1 {
+		history[i], history[j] = history[j], history[i]
+	}
+
+	return history, nil
+}
+
+// assetAsOf rebuilds the value of an asset as of the given date from its key history.
+// It returns nil if the asset did not exist at that date.
+func assetAsOf(stub *sw.StubWrapper, key assets.Key, asOf time.Time) (map[string]interface{}, errors.ICCError) {
+	history, err := keyHistory(stub, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var value map[string]interface{}
+	for _, version := range history {
+		if version.Timestamp.After(asOf) {
+			break
+		}
+		value = version.Value
+	}
+
+	return value, nil
+}
@@ -0,0 +1,203 @@
+package txdefs
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/cardtoken"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// IssueCardToken records a ration card token to be printed as a QR code and verified offline
+// with the cardtoken package. The token is signed off-chain by the calling org with its active
+// issuer key: the transaction only verifies it and records its hash, so the private key never
+// reaches the peers. The token's NID hash must be keyed with the given salt, recorded with it.
+var IssueCardToken = tx.Transaction{
+	Tag:         "issueCardToken",
+	Label:       "Issue Card Token",
+	Description: "Record a ration card token signed off-chain with the active issuer key",
+	Method:      "POST",
+	Callers: []accesscontrol.Caller{ // Only org3 admin can call this transaction
+		{
+			MSP: "org3MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "rationCardNumber",
+			Label:       "Ration Card Number",
+			Description: "Ration Card Number",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "token",
+			Label:       "Token",
+			Description: "Card token signed with the active issuer key, as built by cardtoken.Sign",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "nidSalt",
+			Label:       "NID Salt",
+			Description: "Random base64 salt the NID hash of the token is keyed with",
+			DataType:    "string",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		rationCardNumber, _ := req["rationCardNumber"].(string)
+		token, _ := req["token"].(string)
+		nidSaltB64, _ := req["nidSalt"].(string)
+		nidSalt, nerr := base64.StdEncoding.DecodeString(nidSaltB64)
+		if nerr != nil || len(nidSalt) < cardtoken.SaltSize {
+			return nil, errors.NewCCError(fmt.Sprintf("NID salt must be at least %d bytes of base64", cardtoken.SaltSize), http.StatusBadRequest)
+		}
+
+		// Find the member holding the ration card
+		query := map[string]interface{}{
+			"selector": map[string]interface{}{
+				"@assetType":       "member",
+				"rationCardNumber": rationCardNumber,
+			},
+		}
+		excludeArchived(query)
+		response, err := assets.Search(stub, query, "", false)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "error searching for ration card holder", 500)
+		}
+		if len(response.Result) == 0 {
+			return nil, errors.NewCCError("no member holds this ration card", http.StatusNotFound)
+		}
+		memberMap := response.Result[0]
+		if memberMap["rationCardStatus"] != string(datatypes.RationCardStatusActive) {
+			return nil, errors.NewCCError("ration card is not active", http.StatusForbidden)
+		}
+		cardCategory, ok := memberMap["rationCardCategory"].(float64)
+		if !ok {
+			return nil, errors.NewCCError("ration card has no category", http.StatusBadRequest)
+		}
+
+		// The token must be signed with the calling org's active issuer key
+		issuer, err := stub.GetMSPID()
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to get caller MSP")
+		}
+		activeKeys, err := activeIssuerKeys(stub, issuer)
+		if err != nil {
+			return nil, err
+		}
+		if len(activeKeys) == 0 {
+			return nil, errors.NewCCError(fmt.Sprintf("%s has no active issuer key, publish one with rotateIssuerKey", issuer), http.StatusBadRequest)
+		}
+		keyId, _ := activeKeys[0]["keyId"].(string)
+		publicKeyB64, _ := activeKeys[0]["publicKey"].(string)
+		publicKey, nerr := cardtoken.DecodePublicKey(publicKeyB64)
+		if nerr != nil {
+			return nil, errors.WrapErrorWithStatus(nerr, "invalid issuer key on the ledger", http.StatusInternalServerError)
+		}
+
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+		claims, nerr := cardtoken.Parse(token)
+		if nerr != nil {
+			return nil, errors.WrapErrorWithStatus(nerr, "invalid card token", http.StatusBadRequest)
+		}
+		if claims.KeyID != keyId {
+			return nil, errors.NewCCError(fmt.Sprintf("token must be signed with the active issuer key %s", keyId), http.StatusForbidden)
+		}
+		_, nerr = cardtoken.Verify(token, cardtoken.KeySet{keyId: {PublicKey: publicKey}}, txTimestamp.AsTime())
+		if nerr != nil {
+			return nil, errors.WrapErrorWithStatus(nerr, "card token does not verify", http.StatusForbidden)
+		}
+
+		// The claims must match the card, which the token cannot outlive
+		nid, _ := memberMap["nid"].(string)
+		if claims.CardNumber != rationCardNumber || claims.Category != int(cardCategory) || !cardtoken.MatchNID(claims, nid, nidSalt) {
+			return nil, errors.NewCCError("token claims do not match the ration card", http.StatusBadRequest)
+		}
+		if claims.IssuedAt.After(txTimestamp.AsTime()) {
+			return nil, errors.NewCCError("token is issued in the future", http.StatusBadRequest)
+		}
+		cardExpiry := scheduleDate(memberMap["rationCardExpiryDate"])
+		if !cardExpiry.IsZero() && claims.Expiry.After(cardExpiry) {
+			return nil, errors.NewCCError("token cannot outlive the ration card", http.StatusBadRequest)
+		}
+
+		memberKey, err := assets.NewKey(memberMap)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to get member key")
+		}
+		issuerKey, err := assets.NewKey(activeKeys[0])
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to get issuer key")
+		}
+		tokenHash := cardtoken.Hash(token)
+		tokenAsset, err := assets.NewAsset(map[string]interface{}{
+			"@assetType":       "cardToken",
+			"tokenHash":        tokenHash,
+			"member":           memberKey,
+			"rationCardNumber": rationCardNumber,
+			"nidSalt":          nidSaltB64,
+			"issuerKey":        issuerKey,
+			"issuedDate":       claims.IssuedAt,
+			"expiryDate":       claims.Expiry,
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build card token")
+		}
+		exists, err := tokenAsset.ExistsInLedger(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to check card token existence")
+		}
+		if exists {
+			return nil, errors.NewCCError("card token is already recorded", http.StatusConflict)
+		}
+		tokenMap, err := tokenAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to record card token")
+		}
+
+		tokenJSON, nerr := json.Marshal(tokenMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "cardTokenIssuedLog", memberKey.Key(), fmt.Sprintf("Card token issued for card %s with key %s", rationCardNumber, keyId))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.CardTokenIssuedPayload{
+			EventPayload:     eventPayload,
+			RationCardNumber: rationCardNumber,
+			KeyID:            keyId,
+			TokenHash:        tokenHash,
+			Expiry:           claims.Expiry.Format(time.RFC3339),
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "cardTokenIssuedLog", logMsg)
+
+		return tokenJSON, nil
+	},
+}
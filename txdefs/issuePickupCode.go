@@ -0,0 +1,139 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// IssuePickupCode records the one-time code of a pickup booking. The code is generated at
+// random by org3, which issues the ration cards, delivered to the member out of band and sent
+// as transient data, so only its salted hash reaches the pickupCode private collection, which
+// the operators of org1 do not hold.
+var IssuePickupCode = tx.Transaction{
+	Tag:         "issuePickupCode",
+	Label:       "Issue Pickup Code",
+	Description: "Record the one-time code delivered to a member for a pickup booking",
+	Method:      "POST",
+	Callers: []accesscontrol.Caller{ // Only org3 admin can call this transaction
+		{
+			MSP: "org3MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "pickupBooking",
+			Label:       "Pickup Booking",
+			Description: "Pickup Booking",
+			DataType:    "->pickupBooking",
+			Required:    true,
+		},
+		{
+			Tag:         "pickupCode",
+			Label:       "Pickup Code",
+			Description: "Random one-time code of 16 to 32 base32 characters delivered to the member",
+			DataType:    "string",
+			Required:    true,
+			Private:     true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		bookingKey, ok := req["pickupBooking"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter pickupBooking must be an asset")
+		}
+		pickupCode, _ := req["pickupCode"].(string)
+		if err := checkPickupCode(pickupCode); err != nil {
+			return nil, err
+		}
+
+		bookingMap, err := bookingKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get pickup booking from the ledger", err.Status())
+		}
+		bookingId, _ := bookingMap["bookingId"].(string)
+		if bookingMap["status"] != string(datatypes.PickupBookingStatusBooked) {
+			return nil, errors.NewCCError(fmt.Sprintf("pickup booking is %v", bookingMap["status"]), http.StatusConflict)
+		}
+
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+		expiryDate := scheduleDate(bookingMap["expiryDate"])
+		if !expiryDate.After(txTimestamp.AsTime()) {
+			return nil, errors.NewCCError("pickup booking has expired", http.StatusGone)
+		}
+
+		// A booking has a single code, which cannot be replaced once issued
+		codeAsset, err := assets.NewAsset(map[string]interface{}{
+			"@assetType":     "pickupCode",
+			"booking":        bookingKey,
+			"codeHash":       pickupCodeHash(bookingId, pickupCode),
+			"failedAttempts": 0,
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build pickup code")
+		}
+		exists, err := codeAsset.ExistsInLedger(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to check pickup code existence")
+		}
+		if exists {
+			return nil, errors.NewCCError("a pickup code was already issued for the booking", http.StatusConflict)
+		}
+		_, err = codeAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to record pickup code")
+		}
+
+		distributionPointKey, err := assets.NewKey(map[string]interface{}{"@assetType": "distributionPoint", "@key": referenceKey(bookingMap["distributionPoint"])})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build distribution point key")
+		}
+		distributionPointMap, err := distributionPointKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get distribution point from the ledger", err.Status())
+		}
+		distributionPointId, _ := distributionPointMap["distributionPointId"].(string)
+
+		bookingJSON, nerr := json.Marshal(bookingMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "pickupCodeIssuedLog", bookingKey.Key(), fmt.Sprintf("Pickup code issued for booking %s", bookingId))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.PickupCodeIssuedPayload{
+			EventPayload:        eventPayload,
+			BookingID:           bookingId,
+			DistributionPointID: distributionPointId,
+			ExpiryDate:          expiryDate.Format(time.RFC3339),
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "pickupCodeIssuedLog", logMsg)
+
+		return bookingJSON, nil
+	},
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
 	"github.com/hyperledger-labs/cc-tools/accesscontrol"
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
@@ -12,7 +13,7 @@ import (
 	tx "github.com/hyperledger-labs/cc-tools/transactions"
 )
 
-var IssueRationCard = tx.Transaction{
+var IssueRationCard = requireApproval(tx.Transaction{
 	Tag:         "issueRationCard",
 	Label:       "Issue Ration Card",
 	Description: "Issue a ration card for a member",
@@ -96,6 +97,7 @@ var IssueRationCard = tx.Transaction{
 
 		// Update the member asset with ration card details
 		memberMap := (map[string]interface{})(*memberAsset)
+		statusBefore, _ := memberMap["rationCardStatus"].(string)
 		memberMap["rationCardNumber"] = rationCardNumber
 		memberMap["rationCardStatus"] = rationCardStatus
 		memberMap["rationCardIssuedDate"] = rationCardIssuedDate
@@ -114,7 +116,18 @@ var IssueRationCard = tx.Transaction{
 		}
 
 		// Marshal message to be logged
-		logMsg, erre := json.Marshal(fmt.Sprintf("Ration card issued for member with NID: %s", nid))
+		eventPayload, err := eventtypes.NewEventPayload(stub, "rationCardIssuedLog", memberAsset.Key(), fmt.Sprintf("Ration card issued for member with NID: %s", nid))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, erre := json.Marshal(eventtypes.RationCardIssuedPayload{
+			EventPayload:       eventPayload,
+			NID:                nid,
+			RationCardNumber:   rationCardNumber,
+			RationCardCategory: rationCardCategory,
+			StatusBefore:       statusBefore,
+			StatusAfter:        rationCardStatus,
+		})
 		if erre != nil {
 			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
 		}
@@ -123,4 +136,4 @@ var IssueRationCard = tx.Transaction{
 
 		return updatedMemberJSON, nil
 	},
-}
+})
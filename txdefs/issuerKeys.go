@@ -0,0 +1,24 @@
+package txdefs
+
+import (
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+)
+
+// activeIssuerKeys returns the keys an org currently signs ration card tokens with.
+// rotateIssuerKey keeps a single one, but every match is returned so it can retire them all.
+func activeIssuerKeys(stub *sw.StubWrapper, issuer string) ([]map[string]interface{}, errors.ICCError) {
+	response, err := assets.Search(stub, map[string]interface{}{
+		"selector": map[string]interface{}{
+			"@assetType": "issuerKey",
+			"issuer":     issuer,
+			"status":     datatypes.IssuerKeyStatusActive,
+		},
+	}, "", false)
+	if err != nil {
+		return nil, errors.WrapErrorWithStatus(err, "failed to search issuer keys", err.Status())
+	}
+	return response.Result, nil
+}
@@ -0,0 +1,188 @@
+package txdefs
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+)
+
+// multisigTxs holds the unwrapped high-impact transactions, by tag,
+// so they can be executed once a proposal gathers enough approvals
+var multisigTxs = map[string]tx.Transaction{}
+
+// requireApproval registers a high-impact transaction for multi-signature approval.
+// Once an approval policy is set for its tag, the transaction can no longer be
+// called directly and must go through createProposal and approveProposal, unless
+// its approvalSizes measure the call under the minimum size of the policy.
+func requireApproval(t tx.Transaction) tx.Transaction {
+	multisigTxs[t.Tag] = t
+
+	txTag := t.Tag
+	routine := t.Routine
+	t.Routine = func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		policyTag := approvalPolicyTag(txTag, req)
+		policyMap, err := getApprovalPolicy(stub, policyTag)
+		if err != nil {
+			return nil, err
+		}
+		if policyMap != nil {
+			small, err := belowMinimumSize(stub, txTag, req, policyMap)
+			if err != nil {
+				return nil, err
+			}
+			if !small {
+				return nil, errors.NewCCError(fmt.Sprintf("%s of %s requires multi-signature approval, create a proposal instead", txTag, policyTag), http.StatusForbidden)
+			}
+		}
+
+		return routine(stub, req)
+	}
+	return t
+}
+
+// approvalSizes holds, by transaction tag, how to measure the size of a call, for the
+// transactions whose calls under the minimum size of their policy run without approval
+var approvalSizes = map[string]func(stub *sw.StubWrapper, req map[string]interface{}) (int, errors.ICCError){
+	"replenishInventory": replenishmentSize,
+}
+
+// belowMinimumSize tells whether a call is smaller than the minimum size of the policy of its
+// transaction, and so can run without approval
+func belowMinimumSize(stub *sw.StubWrapper, txTag string, req, policyMap map[string]interface{}) (bool, errors.ICCError) {
+	sizeOf, ok := approvalSizes[txTag]
+	minimumSize := toInt(policyMap["minimumSize"])
+	if !ok || minimumSize <= 0 {
+		return false, nil
+	}
+	size, err := sizeOf(stub, req)
+	if err != nil {
+		return false, err
+	}
+	return size < minimumSize, nil
+}
+
+// policyTargets holds the arg naming the transaction whose approval policy governs a call, by
+// transaction tag, for the transactions which are not governed by their own policy. The policy
+// of a transaction is changed with the approval its current policy requires.
+var policyTargets = map[string]string{
+	"setApprovalPolicy": "txTag",
+}
+
+// approvalPolicyTag returns the tag of the transaction whose approval policy governs a call
+func approvalPolicyTag(txTag string, args map[string]interface{}) string {
+	if arg, ok := policyTargets[txTag]; ok {
+		if target, ok := args[arg].(string); ok {
+			return target
+		}
+	}
+	return txTag
+}
+
+// getApprovalPolicy returns the approval policy of a transaction, or nil if it has none
+func getApprovalPolicy(stub *sw.StubWrapper, txTag string) (map[string]interface{}, errors.ICCError) {
+	policyKey, err := assets.NewKey(map[string]interface{}{
+		"@assetType": "approvalPolicy",
+		"txTag":      txTag,
+	})
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to build approval policy key")
+	}
+
+	exists, err := policyKey.ExistsInLedger(stub)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to check approval policy existence")
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	policyMap, err := policyKey.GetMap(stub)
+	if err != nil {
+		return nil, errors.WrapErrorWithStatus(err, "failed to get approval policy from the ledger", err.Status())
+	}
+	return policyMap, nil
+}
+
+// proposalStub presents the arguments and the identity of the proposer
+// to the transaction wrapped by a proposal
+type proposalStub struct {
+	shim.ChaincodeStubInterface
+	txTag   string
+	args    string
+	creator []byte
+}
+
+func (s proposalStub) GetFunctionAndParameters() (string, []string) {
+	return s.txTag, []string{s.args}
+}
+
+func (s proposalStub) GetTransient() (map[string][]byte, error) {
+	return nil, nil
+}
+
+func (s proposalStub) GetCreator() ([]byte, error) {
+	return s.creator, nil
+}
+
+// executeProposal marks a proposal as executed and runs its transaction on behalf of the proposer
+func executeProposal(stub *sw.StubWrapper, proposalKey assets.Key) ([]byte, errors.ICCError) {
+	proposalMap, err := proposalKey.GetMap(stub)
+	if err != nil {
+		return nil, errors.WrapErrorWithStatus(err, "failed to get proposal from the ledger", err.Status())
+	}
+	txTag, _ := proposalMap["txTag"].(string)
+	args, _ := proposalMap["args"].(string)
+	identity, _ := proposalMap["proposerIdentity"].(string)
+
+	t, ok := multisigTxs[txTag]
+	if !ok {
+		return nil, errors.NewCCError(fmt.Sprintf("transaction %s cannot be executed through a proposal", txTag), http.StatusBadRequest)
+	}
+	creator, nerr := base64.StdEncoding.DecodeString(identity)
+	if nerr != nil {
+		return nil, errors.WrapError(nerr, "failed to decode proposer identity")
+	}
+
+	txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+	if nerr != nil {
+		return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+	}
+	_, err = proposalKey.Update(stub, map[string]interface{}{
+		"status":       "executed",
+		"executedDate": txTimestamp.AsTime(),
+	})
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to update proposal")
+	}
+
+	// The write sets are shared so both stubs see the writes of each other
+	if stub.WriteSet == nil {
+		stub.WriteSet = make(map[string][]byte)
+	}
+	if stub.PvtWriteSet == nil {
+		stub.PvtWriteSet = make(map[string]map[string][]byte)
+	}
+	proposerStub := &sw.StubWrapper{
+		Stub: proposalStub{
+			ChaincodeStubInterface: stub.Stub,
+			txTag:                  txTag,
+			args:                   args,
+			creator:                creator,
+		},
+		WriteSet:    stub.WriteSet,
+		PvtWriteSet: stub.PvtWriteSet,
+	}
+
+	req, err := t.GetArgs(proposerStub.Stub)
+	if err != nil {
+		return nil, errors.WrapError(err, "unable to get proposal args")
+	}
+
+	return t.Routine(proposerStub, req)
+}
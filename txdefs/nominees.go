@@ -0,0 +1,84 @@
+package txdefs
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+)
+
+// MaxCardsPerNominee is the number of members a nominee may collect rations for at once
+const MaxCardsPerNominee = 3
+
+// nomineeActive checks if a nominee may collect at a given time
+func nomineeActive(nomineeMap map[string]interface{}, at time.Time) bool {
+	if removed, _ := nomineeMap["removed"].(bool); removed {
+		return false
+	}
+	return !scheduleDate(nomineeMap["validFrom"]).After(at) && scheduleDate(nomineeMap["validUntil"]).After(at)
+}
+
+// nomineeCards counts the members other than principalKey a nominee is authorized to collect
+// for, now or later
+func nomineeCards(stub *sw.StubWrapper, nid string, principalKey string, now time.Time) (int, errors.ICCError) {
+	response, err := assets.Search(stub, map[string]interface{}{
+		"selector": map[string]interface{}{
+			"@assetType": "nominee",
+			"nid":        nid,
+			"removed":    false,
+		},
+	}, "", false)
+	if err != nil {
+		return 0, errors.WrapErrorWithStatus(err, "failed to search nominees", err.Status())
+	}
+
+	cards := 0
+	for _, nomineeMap := range response.Result {
+		if referenceKey(nomineeMap["principal"]) != principalKey && scheduleDate(nomineeMap["validUntil"]).After(now) {
+			cards++
+		}
+	}
+	return cards, nil
+}
+
+// collectorOf returns who collects a ration for a member given the NID of the person at the
+// distribution point, and the key of the nominee if it is not the member. Anyone else than
+// the member or one of their active nominees is refused.
+func collectorOf(stub *sw.StubWrapper, memberMap map[string]interface{}, collectorNid string, at time.Time) (datatypes.Collector, assets.Key, errors.ICCError) {
+	if collectorNid == "" || collectorNid == memberMap["nid"] {
+		return datatypes.CollectorPrincipal, nil, nil
+	}
+
+	memberKey, err := assets.NewKey(memberMap)
+	if err != nil {
+		return "", nil, errors.WrapError(err, "failed to get member key")
+	}
+	nomineeKey, err := assets.NewKey(map[string]interface{}{
+		"@assetType": "nominee",
+		"principal":  memberKey,
+		"nid":        collectorNid,
+	})
+	if err != nil {
+		return "", nil, errors.WrapErrorWithStatus(err, "invalid collector NID", http.StatusBadRequest)
+	}
+	exists, err := nomineeKey.ExistsInLedger(stub)
+	if err != nil {
+		return "", nil, errors.WrapError(err, "failed to check nominee existence")
+	}
+	if !exists {
+		return "", nil, errors.NewCCError(fmt.Sprintf("%s is not a nominee of the ration card holder", collectorNid), http.StatusForbidden)
+	}
+	nomineeMap, err := nomineeKey.GetMap(stub)
+	if err != nil {
+		return "", nil, errors.WrapError(err, "failed to get nominee from the ledger")
+	}
+	if !nomineeActive(nomineeMap, at) {
+		return "", nil, errors.NewCCError(fmt.Sprintf("nominee %s is not authorized to collect at this date", collectorNid), http.StatusForbidden)
+	}
+
+	return datatypes.CollectorNominee, nomineeKey, nil
+}
@@ -0,0 +1,62 @@
+package txdefs
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	"github.com/hyperledger/fabric-chaincode-go/pkg/cid"
+)
+
+// operatorIdAttribute is the X.509 certificate attribute holding the operator ID of a caller
+const operatorIdAttribute = "operatorId"
+
+// operatorAssignmentKey builds the key of the assignment of an operator of an MSP to a distribution point
+func operatorAssignmentKey(operatorMsp, operatorId, distributionPointId string) (assets.Key, errors.ICCError) {
+	return assets.NewKey(map[string]interface{}{
+		"@assetType":  "operatorAssignment",
+		"operatorMsp": operatorMsp,
+		"operatorId":  operatorId,
+		"distributionPoint": map[string]interface{}{
+			"@assetType":          "distributionPoint",
+			"distributionPointId": distributionPointId,
+		},
+	})
+}
+
+// checkOperatorAssignment rejects callers which are not assigned to the distribution point they act on.
+// Callers from orgMSP supervise every distribution point and are not bound to assignments.
+func checkOperatorAssignment(stub *sw.StubWrapper, distributionPointId string) errors.ICCError {
+	mspId, err := stub.GetMSPID()
+	if err != nil {
+		return errors.WrapError(err, "failed to get caller MSP")
+	}
+	if mspId == "orgMSP" {
+		return nil
+	}
+
+	operatorId, found, nerr := cid.GetAttributeValue(stub.Stub, operatorIdAttribute)
+	if nerr != nil {
+		return errors.WrapError(nerr, "failed to read caller attributes")
+	}
+	if !found || operatorId == "" {
+		return errors.NewCCError("caller certificate has no operator ID", http.StatusForbidden)
+	}
+
+	// Operator IDs are only unique within the MSP which issued the certificate
+	key, err := operatorAssignmentKey(mspId, operatorId, distributionPointId)
+	if err != nil {
+		return errors.WrapError(err, "failed to build operator assignment key")
+	}
+	assigned, err := key.ExistsInLedger(stub)
+	if err != nil {
+		return errors.WrapError(err, "failed to check operator assignment")
+	}
+	if !assigned {
+		return errors.NewCCError(fmt.Sprintf("operator %s of %s is not assigned to distribution point %s", operatorId, mspId, distributionPointId), http.StatusForbidden)
+	}
+
+	return nil
+}
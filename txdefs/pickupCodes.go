@@ -0,0 +1,62 @@
+package txdefs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+)
+
+// MaxPickupCodeAttempts is the number of wrong codes after which a pickup booking is locked
+const MaxPickupCodeAttempts = 5
+
+// pickupCodeValidity is how long after its pickup date a booking's code can be used
+const pickupCodeValidity = 24 * time.Hour
+
+// pickupCodePattern matches pickup codes of 16 to 32 base32 characters, 80 to 160 random bits
+// printed as a QR code, which cannot be guessed from their hash
+var pickupCodePattern = regexp.MustCompile(`^[A-Z2-7]{16,32}$`)
+
+// checkPickupCode checks the format of a one-time pickup code
+func checkPickupCode(code string) errors.ICCError {
+	if !pickupCodePattern.MatchString(code) {
+		return errors.NewCCError("pickup code must be 16 to 32 base32 characters", http.StatusBadRequest)
+	}
+	return nil
+}
+
+// pickupCodeHash hashes a pickup code salted with its booking ID, so equal codes of two
+// bookings do not hash alike
+func pickupCodeHash(bookingId, code string) string {
+	hash := sha256.Sum256([]byte(bookingId + ":" + code))
+	return hex.EncodeToString(hash[:])
+}
+
+// getPickupCode reads the pickup code issued for a booking. The pickupCode collection is only
+// held by the org3 peers, which must endorse the transactions checking codes.
+func getPickupCode(stub *sw.StubWrapper, bookingKey assets.Key) (assets.Key, map[string]interface{}, errors.ICCError) {
+	codeKey, err := assets.NewKey(map[string]interface{}{
+		"@assetType": "pickupCode",
+		"booking":    bookingKey,
+	})
+	if err != nil {
+		return nil, nil, errors.WrapError(err, "failed to build pickup code key")
+	}
+	exists, err := codeKey.ExistsInLedger(stub)
+	if err != nil {
+		return nil, nil, errors.WrapError(err, "failed to check pickup code existence")
+	}
+	if !exists {
+		return nil, nil, errors.NewCCError("no pickup code was issued for the booking", http.StatusNotFound)
+	}
+	codeMap, err := codeKey.GetMap(stub)
+	if err != nil {
+		return nil, nil, errors.WrapErrorWithStatus(err, "failed to get pickup code from the ledger", err.Status())
+	}
+	return codeKey, codeMap, nil
+}
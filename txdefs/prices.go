@@ -0,0 +1,85 @@
+package txdefs
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+)
+
+// saleMonthLayout formats the month of a sale, the period subsidies are reimbursed over
+const saleMonthLayout = "2006-01"
+
+// priceScheduleAt returns the price schedule of a ration category for a ration card category
+// in force at a date. Schedules run from their start date until their end date, or until the
+// next schedule starts when they are open-ended, so the latest one started wins.
+func priceScheduleAt(stub *sw.StubWrapper, category datatypes.RationCategory, cardCategory datatypes.RationCardCategory, date time.Time) (map[string]interface{}, errors.ICCError) {
+	response, err := assets.Search(stub, map[string]interface{}{
+		"selector": map[string]interface{}{
+			"@assetType":   "priceSchedule",
+			"category":     category,
+			"cardCategory": cardCategory,
+		},
+	}, "", false)
+	if err != nil {
+		return nil, errors.WrapErrorWithStatus(err, "failed to search price schedules", err.Status())
+	}
+
+	var current map[string]interface{}
+	var currentStart time.Time
+	for _, schedule := range response.Result {
+		startDate, endDate := scheduleDate(schedule["startDate"]), scheduleDate(schedule["endDate"])
+		if startDate.After(date) || (!endDate.IsZero() && endDate.Before(date)) {
+			continue
+		}
+		if current == nil || startDate.After(currentStart) {
+			current, currentStart = schedule, startDate
+		}
+	}
+	if current == nil {
+		return nil, errors.NewCCError(fmt.Sprintf("no price schedule of %s for %s ration cards on %s", category.Label(), cardCategory.Label(), date.Format("2006-01-02")), http.StatusBadRequest)
+	}
+
+	return current, nil
+}
+
+// scheduleDate reads a datetime prop of a schedule read from the ledger, zero if unset
+func scheduleDate(value interface{}) time.Time {
+	switch v := value.(type) {
+	case time.Time:
+		return v
+	case string:
+		date, _ := time.Parse(time.RFC3339, v)
+		return date
+	}
+	return time.Time{}
+}
+
+// salePrice returns the amount a member pays for an amount of a ration category, in its base
+// unit, and the subsidy the distributor claims for it under a price schedule
+func salePrice(schedule map[string]interface{}, category datatypes.RationCategory, amount int) (datatypes.Money, datatypes.Money, errors.ICCError) {
+	marketPrice, err := datatypes.MoneyOf(schedule["marketPrice"])
+	if err != nil {
+		return 0, 0, errors.WrapErrorWithStatus(err, "invalid market price", err.Status())
+	}
+	subsidizedPrice, err := datatypes.MoneyOf(schedule["subsidizedPrice"])
+	if err != nil {
+		return 0, 0, errors.WrapErrorWithStatus(err, "invalid subsidized price", err.Status())
+	}
+	priceUnit, _ := schedule["unit"].(string)
+
+	// Prices are quoted per price unit, e.g. per kg for grains counted in g
+	perPriceUnit, err := category.BaseAmount(datatypes.Quantity{Value: 1, Unit: datatypes.Unit(priceUnit)})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	amountPaid := subsidizedPrice.MulRatio(int64(amount), int64(perPriceUnit))
+	subsidyClaimed := (marketPrice - subsidizedPrice).MulRatio(int64(amount)*int64(toInt(schedule["subsidyShare"])), int64(perPriceUnit)*100)
+
+	return amountPaid, subsidyClaimed, nil
+}
@@ -0,0 +1,140 @@
+package txdefs
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+)
+
+// pickupDayLayout formats the day of a pickup, whose slots are counted by day
+const pickupDayLayout = "2006-01-02"
+
+// priority is the priority service a member is given by the classes they belong to
+type priority struct {
+	Classes          []datatypes.PriorityCriterion `json:"classes"`
+	EntitlementBonus int                           `json:"entitlementBonus"` // Percentage, the largest of the classes
+	HomeDelivery     bool                          `json:"homeDelivery"`
+}
+
+// activePriorityClasses returns the priority classes in force
+func activePriorityClasses(stub *sw.StubWrapper) ([]map[string]interface{}, errors.ICCError) {
+	response, err := assets.Search(stub, map[string]interface{}{
+		"selector": map[string]interface{}{
+			"@assetType": "priorityClass",
+			"active":     true,
+		},
+	}, "", false)
+	if err != nil {
+		return nil, errors.WrapErrorWithStatus(err, "failed to search priority classes", err.Status())
+	}
+	return response.Result, nil
+}
+
+// ageAt returns the age in full years at a given time of someone born on dateOfBirth
+func ageAt(dateOfBirth, at time.Time) int {
+	age := at.Year() - dateOfBirth.Year()
+	if at.Month() < dateOfBirth.Month() || (at.Month() == dateOfBirth.Month() && at.Day() < dateOfBirth.Day()) {
+		age--
+	}
+	return age
+}
+
+// memberPriority returns the priority service of a member at a given time among classes
+func memberPriority(memberMap map[string]interface{}, classes []map[string]interface{}, at time.Time) priority {
+	p := priority{Classes: []datatypes.PriorityCriterion{}}
+	for _, class := range classes {
+		criterionValue, _ := class["criterion"].(string)
+		criterion := datatypes.PriorityCriterion(criterionValue)
+		eligible := false
+		switch criterion {
+		case datatypes.PriorityCriterionDisability:
+			eligible, _ = memberMap["disabilityStatus"].(bool)
+		case datatypes.PriorityCriterionElderly:
+			dateOfBirth := scheduleDate(memberMap["dateOfBirth"])
+			minimumAge := toInt(class["minimumAge"])
+			eligible = !dateOfBirth.IsZero() && minimumAge > 0 && ageAt(dateOfBirth, at) >= minimumAge
+		case datatypes.PriorityCriterionPregnancy:
+			eligible, _ = memberMap["pregnant"].(bool)
+		}
+		if !eligible {
+			continue
+		}
+
+		p.Classes = append(p.Classes, criterion)
+		if bonus := toInt(class["entitlementBonus"]); bonus > p.EntitlementBonus {
+			p.EntitlementBonus = bonus
+		}
+		if homeDelivery, _ := class["homeDelivery"].(bool); homeDelivery {
+			p.HomeDelivery = true
+		}
+	}
+	return p
+}
+
+// eligible checks if the member belongs to any priority class
+func (p priority) eligible() bool {
+	return len(p.Classes) > 0
+}
+
+// entitlement returns the quantity of a ration a member may collect
+func (p priority) entitlement(rationQuantity int) int {
+	return rationQuantity + rationQuantity*p.EntitlementBonus/100
+}
+
+// reservedSlots returns how many of the daily pickup slots of a distribution point are
+// reserved to priority members, the shares of the classes adding up
+func reservedSlots(capacity int, classes []map[string]interface{}) int {
+	share := 0
+	for _, class := range classes {
+		share += toInt(class["reservedSlotShare"])
+	}
+	if share > 100 {
+		share = 100
+	}
+	return capacity * share / 100
+}
+
+// checkPickupSlot checks a distribution point has a pickup slot left on the day of a pickup.
+// The capacity of the point is its number of pickups a day, unlimited when not set; members
+// without priority cannot take the slots reserved to priority members.
+func checkPickupSlot(stub *sw.StubWrapper, distributionPointMap map[string]interface{}, pickupDate time.Time, p priority, classes []map[string]interface{}) errors.ICCError {
+	capacity := toInt(distributionPointMap["capacity"])
+	if capacity <= 0 {
+		return nil
+	}
+
+	dayStart := pickupDate.UTC().Truncate(24 * time.Hour)
+	day := dayStart.Format(pickupDayLayout)
+	response, err := assets.Search(stub, map[string]interface{}{
+		"selector": map[string]interface{}{
+			"@assetType":             "pickupBooking",
+			"distributionPoint.@key": distributionPointMap["@key"],
+			"pickupDate":             dateRange(dayStart, dayStart.AddDate(0, 0, 1)),
+			"status":                 map[string]interface{}{"$ne": string(datatypes.PickupBookingStatusLocked)},
+		},
+	}, "", false)
+	if err != nil {
+		return errors.WrapErrorWithStatus(err, "failed to search pickup bookings", err.Status())
+	}
+
+	booked, bookedPriority := 0, 0
+	for _, bookingMap := range response.Result {
+		booked++
+		if isPriority, _ := bookingMap["priority"].(bool); isPriority {
+			bookedPriority++
+		}
+	}
+
+	if booked >= capacity {
+		return errors.NewCCError(fmt.Sprintf("no pickup slot left on %s", day), http.StatusConflict)
+	}
+	if !p.eligible() && booked-bookedPriority >= capacity-reservedSlots(capacity, classes) {
+		return errors.NewCCError(fmt.Sprintf("the pickup slots left on %s are reserved to priority members", day), http.StatusConflict)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package txdefs
+
+import (
+	"encoding/json"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+// getPurchaseOrderLines decodes the line items of a purchase order read from the ledger
+func getPurchaseOrderLines(purchaseOrderMap map[string]interface{}) ([]datatypes.PurchaseOrderLine, errors.ICCError) {
+	linesJSON, nerr := json.Marshal(purchaseOrderMap["lineItems"])
+	if nerr != nil {
+		return nil, errors.WrapError(nerr, "failed to encode purchase order line items")
+	}
+
+	var lines []datatypes.PurchaseOrderLine
+	nerr = json.Unmarshal(linesJSON, &lines)
+	if nerr != nil {
+		return nil, errors.WrapError(nerr, "failed to decode purchase order line items")
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,51 @@
+package txdefs
+
+import (
+	"sort"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/errors"
+)
+
+// rationAmount returns the category of a ration read from the ledger and its quantity
+// in the base unit of the category
+func rationAmount(rationMap map[string]interface{}) (datatypes.RationCategory, int, errors.ICCError) {
+	category := datatypes.RationCategory(toInt(rationMap["category"]))
+	quantity, err := datatypes.QuantityOf(rationMap["quantity"])
+	if err != nil {
+		return category, 0, errors.WrapErrorWithStatus(err, "invalid ration quantity", err.Status())
+	}
+	amount, err := category.BaseAmount(quantity)
+	if err != nil {
+		return category, 0, err
+	}
+	return category, amount, nil
+}
+
+// quantityTotals sums amounts of several ration categories by base unit, as amounts
+// in different units cannot be added up
+type quantityTotals map[datatypes.Unit]int
+
+// add adds an amount in the base unit of a category
+func (t quantityTotals) add(category datatypes.RationCategory, amount int) {
+	t[category.BaseUnit()] += amount
+}
+
+// merge adds up the totals of another set
+func (t quantityTotals) merge(other quantityTotals) {
+	for unit, amount := range other {
+		t[unit] += amount
+	}
+}
+
+// list returns the totals as quantities, sorted by unit
+func (t quantityTotals) list() []datatypes.Quantity {
+	quantities := []datatypes.Quantity{}
+	for unit, amount := range t {
+		quantities = append(quantities, datatypes.Quantity{Value: float64(amount), Unit: unit})
+	}
+	sort.Slice(quantities, func(i, j int) bool {
+		return quantities[i].Unit < quantities[j].Unit
+	})
+	return quantities
+}
@@ -0,0 +1,129 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// RaiseLowStockAlert sends a low stock alert of a distribution point to the procurement channel
+var RaiseLowStockAlert = tx.Transaction{
+	Tag:         "raiseLowStockAlert",
+	Label:       "Raise Low Stock Alert",
+	Description: "Send a low stock alert of a distribution point to the procurement channel",
+	Method:      "POST",
+	Callers: []accesscontrol.Caller{ // Only org1 admin can call this transaction
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "distributionPoint",
+			Label:       "Distribution Point",
+			Description: "Distribution point running low",
+			DataType:    "->distributionPoint",
+			Required:    true,
+		},
+		{
+			Tag:         "category",
+			Label:       "Ration Category",
+			Description: "Ration Category running low",
+			DataType:    "rationCategory",
+			Required:    true,
+		},
+		{
+			Tag:         "currentLevel",
+			Label:       "Current Stock Level",
+			Description: "Current Stock Level, in any unit of the category",
+			DataType:    "quantity",
+			Required:    true,
+		},
+		{
+			Tag:         "suggestedQuantity",
+			Label:       "Suggested Replenishment Quantity",
+			Description: "Suggested Replenishment Quantity, in any unit of the category",
+			DataType:    "quantity",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		distributionPointKey, ok := req["distributionPoint"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter distributionPoint must be an asset")
+		}
+		category, _ := req["category"].(datatypes.RationCategory)
+
+		distributionPointMap, err := distributionPointKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get distribution point from the ledger", err.Status())
+		}
+		distributionPointId, _ := distributionPointMap["distributionPointId"].(string)
+
+		// Only operators assigned to the distribution point can act on it
+		if err := checkOperatorAssignment(stub, distributionPointId); err != nil {
+			return nil, err
+		}
+
+		currentLevel, err := category.BaseAmount(req["currentLevel"].(datatypes.Quantity))
+		if err != nil {
+			return nil, err
+		}
+		suggestedQuantity, err := category.BaseAmount(req["suggestedQuantity"].(datatypes.Quantity))
+		if err != nil {
+			return nil, err
+		}
+		if suggestedQuantity <= 0 {
+			return nil, errors.NewCCError("suggested quantity must be greater than 0", http.StatusBadRequest)
+		}
+
+		alert, err := newLowStockAlert(stub, distributionPointKey.Key(), distributionPointId, category, currentLevel, suggestedQuantity)
+		if err != nil {
+			return nil, err
+		}
+
+		alertJSON, nerr := json.Marshal(alert)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		err = events.CallEvent(stub, "lowStockAlert", alertJSON)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to emit event")
+		}
+
+		return alertJSON, nil
+	},
+}
+
+// newLowStockAlert builds the payload of a lowStockAlert event, with levels in the base unit of the category
+func newLowStockAlert(stub *sw.StubWrapper, assetKey, distributionPointId string, category datatypes.RationCategory, currentLevel, suggestedQuantity int) (*eventtypes.LowStockAlertPayload, errors.ICCError) {
+	eventPayload, err := eventtypes.NewEventPayload(stub, "lowStockAlert", assetKey, fmt.Sprintf("Distribution point %s is low on %s: %d %s left", distributionPointId, category.Label(), currentLevel, category.BaseUnit()))
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to build event payload")
+	}
+
+	return &eventtypes.LowStockAlertPayload{
+		EventPayload:        eventPayload,
+		SourceChannel:       stub.Stub.GetChannelID(),
+		DistributionPointID: distributionPointId,
+		Category:            category,
+		CurrentLevel:        currentLevel,
+		SuggestedQuantity:   suggestedQuantity,
+		Unit:                category.BaseUnit(),
+	}, nil
+}
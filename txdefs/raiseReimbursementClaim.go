@@ -0,0 +1,181 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// RaiseReimbursementClaim claims the subsidy of the sales of a distributor over a month.
+// The claim is populated from the sales recorded by buyRation, each of which can only be
+// claimed once. Offline sales replayed after the claim of their month are picked up by the
+// claim of the month they were replayed in.
+var RaiseReimbursementClaim = tx.Transaction{
+	Tag:         "raiseReimbursementClaim",
+	Label:       "Raise Reimbursement Claim",
+	Description: "Claim the subsidy of the ration sales of a distributor over a month",
+	Method:      "POST",
+	Callers: []accesscontrol.Caller{ // Only org1 admin can call this transaction
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "distributor",
+			Label:       "Distributor",
+			Description: "Distributor claiming the subsidy",
+			DataType:    "->distributor",
+			Required:    true,
+		},
+		{
+			Tag:         "month",
+			Label:       "Month",
+			Description: "Month of the sales (YYYY-MM), which must be over",
+			DataType:    "string",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		distributorKey, ok := req["distributor"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter distributor must be an asset")
+		}
+		month, _ := req["month"].(string)
+		monthStart, nerr := time.Parse(saleMonthLayout, month)
+		if nerr != nil {
+			return nil, errors.NewCCError("month must be formatted as YYYY-MM", http.StatusBadRequest)
+		}
+
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+		// Sales can still be recorded until the month is over
+		if !monthStart.AddDate(0, 1, 0).Before(txTimestamp.AsTime()) {
+			return nil, errors.NewCCError(fmt.Sprintf("claims for %s can only be raised once the month is over", month), http.StatusBadRequest)
+		}
+
+		distributorMap, err := distributorKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get distributor from the ledger", err.Status())
+		}
+		distributorId, _ := distributorMap["distributorId"].(string)
+
+		claimMap := map[string]interface{}{
+			"@assetType":  "reimbursementClaim",
+			"distributor": distributorKey,
+			"month":       month,
+		}
+		claimKey, err := assets.NewKey(claimMap)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build reimbursement claim key")
+		}
+		exists, err := claimKey.ExistsInLedger(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to check reimbursement claim existence")
+		}
+		if exists {
+			return nil, errors.NewCCError(fmt.Sprintf("distributor %s already claimed %s", distributorId, month), http.StatusConflict)
+		}
+
+		response, err := assets.Search(stub, map[string]interface{}{
+			"selector": map[string]interface{}{
+				"@assetType":       "rationSale",
+				"distributor.@key": distributorKey.Key(),
+				"$or": []interface{}{
+					map[string]interface{}{"claimMonth": month},
+					map[string]interface{}{"month": month, "claimMonth": map[string]interface{}{"$exists": false}},
+				},
+			},
+		}, "", false)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to search ration sales", err.Status())
+		}
+
+		saleKeys := []interface{}{}
+		amountClaimed := datatypes.Money(0)
+		for _, sale := range response.Result {
+			if referenceKey(sale["claim"]) != "" {
+				continue
+			}
+			subsidyClaimed, err := datatypes.MoneyOf(sale["subsidyClaimed"])
+			if err != nil {
+				return nil, errors.WrapErrorWithStatus(err, "invalid subsidy claimed", err.Status())
+			}
+			saleKey, err := assets.NewKey(sale)
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to build ration sale key")
+			}
+			saleKeys = append(saleKeys, saleKey)
+			amountClaimed += subsidyClaimed
+		}
+		if len(saleKeys) == 0 {
+			return nil, errors.NewCCError(fmt.Sprintf("distributor %s has no sales to claim in %s", distributorId, month), http.StatusBadRequest)
+		}
+
+		claimMap["sales"] = saleKeys
+		claimMap["amountClaimed"] = amountClaimed
+		claimMap["status"] = datatypes.ReimbursementClaimStatusRaised
+		claimMap["raisedDate"] = txTimestamp.AsTime()
+		claimAsset, err := assets.NewAsset(claimMap)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to create a new asset")
+		}
+		claimAssetMap, err := claimAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Error saving asset on blockchain")
+		}
+
+		// Tie the sales to the claim, so they cannot be claimed again
+		for _, saleRef := range saleKeys {
+			saleKey := saleRef.(assets.Key)
+			_, err := saleKey.Update(stub, map[string]interface{}{
+				"claim": claimKey,
+			})
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to update ration sale")
+			}
+		}
+
+		claimJSON, nerr := json.Marshal(claimAssetMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "reimbursementClaimRaisedLog", claimAsset.Key(), fmt.Sprintf("Distributor %s claimed %s for %s", distributorId, amountClaimed, month))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.ReimbursementClaimRaisedPayload{
+			EventPayload:  eventPayload,
+			DistributorID: distributorId,
+			Month:         month,
+			Sales:         len(saleKeys),
+			AmountClaimed: amountClaimed,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "reimbursementClaimRaisedLog", logMsg)
+
+		return claimJSON, nil
+	},
+}
@@ -0,0 +1,60 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// ReadAsset is the cc-tools readAsset transaction with an asOf option, rebuilding the value
+// a domain asset had at that date from its history. References are not resolved for past
+// values, as they would be read at their current state.
+// It takes precedence over the cc-tools one in the tx list.
+//
+// The domain transactions reading assets by key, readRation and getPickupSchedule, take asOf
+// too. Those aggregating CouchDB queries, such as readTotalRationsByDistributionPoint or the
+// region and subsidy reports, do not: CouchDB only indexes current values, so past ones could
+// only be rebuilt from the history of every asset of the type. getDistributionPointStockAsOf
+// rebuilds the past stock of a point instead.
+var ReadAsset = func() tx.Transaction {
+	t := tx.ReadAsset
+	t.Args = append(append(tx.ArgList{}, t.Args...), tx.Argument{
+		Tag:         "asOf",
+		Description: "Read the value the asset had at this date.",
+		DataType:    "datetime",
+	})
+	routine := t.Routine
+	t.Routine = func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		asOf, ok := req["asOf"].(time.Time)
+		if !ok {
+			return routine(stub, req)
+		}
+
+		key, _ := req["key"].(assets.Key)
+		if !domainAssetTypes[key.TypeTag()] {
+			return nil, errors.NewCCError(fmt.Sprintf("asOf is not supported for %s assets", key.TypeTag()), http.StatusBadRequest)
+		}
+
+		assetMap, err := assetAsOf(stub, key, asOf)
+		if err != nil {
+			return nil, err
+		}
+		if assetMap == nil {
+			return nil, errors.NewCCError(fmt.Sprintf("asset did not exist on %s", asOf.Format(time.RFC3339)), http.StatusNotFound)
+		}
+
+		assetJSON, nerr := json.Marshal(assetMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		return assetJSON, nil
+	}
+	return t
+}()
@@ -0,0 +1,30 @@
+package txdefs
+
+import (
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var ReadDistributorHistory = tx.Transaction{
+	Tag:         "readDistributorHistory",
+	Label:       "Read Distributor History",
+	Description: "Read every version of a distributor with the changes made by each transaction",
+	Method:      "GET",
+	ReadOnly:    true,
+	Callers: []accesscontrol.Caller{ // Only org1 admin can call this transaction
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: historyArgs("distributor", "Distributor"),
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		return readHistory(stub, req, "distributor")
+	},
+}
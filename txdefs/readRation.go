@@ -2,6 +2,7 @@ package txdefs
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/hyperledger-labs/cc-tools/accesscontrol"
 	"github.com/hyperledger-labs/cc-tools/assets"
@@ -10,6 +11,8 @@ import (
 	tx "github.com/hyperledger-labs/cc-tools/transactions"
 )
 
+// ReadRation reads a ration by its ID. With asOf, it rebuilds the value the ration had at that
+// date from its history, as readAsset does; rations deleted since cannot be found.
 var ReadRation = tx.Transaction{
 	Tag:         "readRation",
 	Label:       "Read Ration",
@@ -33,21 +36,27 @@ var ReadRation = tx.Transaction{
 			DataType:    "string",
 			Required:    true,
 		},
+		{
+			Tag:         "asOf",
+			Label:       "As Of",
+			Description: "Read the value the ration had at this date",
+			DataType:    "datetime",
+		},
 	},
 	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
-
-		name, ok := req["name"].(string)
-		if !ok {
-			return nil, errors.NewCCError("name is required", 400)
-		}
+		id, _ := req["id"].(string)
 
 		// Prepare couchdb query
 		query := map[string]interface{}{
 			"selector": map[string]interface{}{
 				"@assetType": "ration",
-				"name":       name,
+				"id":         id,
 			},
 		}
+		asOf, past := req["asOf"].(time.Time)
+		if !past {
+			excludeArchived(query)
+		}
 
 		// Retrieve the ration asset
 		rationAsset, err := assets.Search(stub, query, "", true)
@@ -55,10 +64,29 @@ var ReadRation = tx.Transaction{
 			return nil, errors.WrapError(err, "failed to get ration asset from the ledger")
 		}
 
+		if past {
+			// Rebuild the rations which existed, unarchived, at that date
+			rations := []map[string]interface{}{}
+			for _, rationMap := range rationAsset.Result {
+				key, err := assets.NewKey(rationMap)
+				if err != nil {
+					return nil, errors.WrapError(err, "failed to get ration key")
+				}
+				pastMap, err := assetAsOf(stub, key, asOf)
+				if err != nil {
+					return nil, err
+				}
+				if pastMap != nil && pastMap["archived"] != true {
+					rations = append(rations, pastMap)
+				}
+			}
+			rationAsset.Result = rations
+		}
+
 		// Marshal asset back to JSON format
 		rationJSON, nerr := json.Marshal(rationAsset)
 		if nerr != nil {
-			return nil, errors.WrapError(err, "failed to marshal response")
+			return nil, errors.WrapError(nerr, "failed to marshal response")
 		}
 
 		return rationJSON, nil
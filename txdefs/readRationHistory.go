@@ -0,0 +1,30 @@
+package txdefs
+
+import (
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var ReadRationHistory = tx.Transaction{
+	Tag:         "readRationHistory",
+	Label:       "Read Ration History",
+	Description: "Read every version of a ration with the changes made by each transaction",
+	Method:      "GET",
+	ReadOnly:    true,
+	Callers: []accesscontrol.Caller{ // Only org2 admin can call this transaction
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: historyArgs("ration", "Ration"),
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		return readHistory(stub, req, "ration")
+	},
+}
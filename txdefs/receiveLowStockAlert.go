@@ -0,0 +1,174 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// ReceiveLowStockAlert records a lowStockAlert coming from a distribution point channel
+// as a restockRequest. It is called by the CCAPI on the procurement channel.
+var ReceiveLowStockAlert = tx.Transaction{
+	Tag:         "receiveLowStockAlert",
+	Label:       "Receive Low Stock Alert",
+	Description: "Record a low stock alert from a distribution point channel as a restock request",
+	Method:      "POST",
+	Callers: []accesscontrol.Caller{ // Only org2 admin can call this transaction
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "txId",
+			Label:       "Source Transaction ID",
+			Description: "ID of the transaction which raised the alert",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "sourceChannel",
+			Label:       "Source Channel",
+			Description: "Channel of the distribution point",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "distributionPointId",
+			Label:       "Distribution Point ID",
+			Description: "Distribution Point ID",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "category",
+			Label:       "Ration Category",
+			Description: "Ration Category",
+			DataType:    "rationCategory",
+			Required:    true,
+		},
+		{
+			Tag:         "currentLevel",
+			Label:       "Current Stock Level",
+			Description: "Current Stock Level",
+			DataType:    "integer",
+			Required:    true,
+		},
+		{
+			Tag:         "suggestedQuantity",
+			Label:       "Suggested Replenishment Quantity",
+			Description: "Suggested Replenishment Quantity",
+			DataType:    "integer",
+			Required:    true,
+		},
+		{
+			Tag:         "unit",
+			Label:       "Unit",
+			Description: "Unit of the levels, defaults to the base unit of the category",
+			DataType:    "unit",
+			Required:    false,
+		},
+		{
+			Tag:         "timestamp",
+			Label:       "Alert Date",
+			Description: "Date the alert was raised",
+			DataType:    "datetime",
+			Required:    false,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		requestId, _ := req["txId"].(string)
+		category, _ := req["category"].(datatypes.RationCategory)
+		unit, ok := req["unit"].(datatypes.Unit)
+		if !ok {
+			unit = category.BaseUnit()
+		}
+		alertDate, ok := req["timestamp"].(time.Time)
+		if !ok {
+			txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+			if nerr != nil {
+				return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+			}
+			alertDate = txTimestamp.AsTime()
+		}
+
+		restockRequestMap := make(map[string]interface{})
+		restockRequestMap["@assetType"] = "restockRequest"
+		restockRequestMap["requestId"] = requestId
+
+		// Alerts may be delivered more than once, in which case the existing request is returned
+		restockRequestKey, err := assets.NewKey(restockRequestMap)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build restock request key")
+		}
+		exists, err := restockRequestKey.ExistsInLedger(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to check restock request existence")
+		}
+		if exists {
+			return restockRequestKey.GetBytes(stub)
+		}
+
+		restockRequestMap["sourceChannel"] = req["sourceChannel"]
+		restockRequestMap["distributionPointId"] = req["distributionPointId"]
+		restockRequestMap["category"] = category
+		restockRequestMap["currentLevel"] = toInt(req["currentLevel"])
+		restockRequestMap["suggestedQuantity"] = toInt(req["suggestedQuantity"])
+		restockRequestMap["unit"] = unit
+		restockRequestMap["alertDate"] = alertDate
+		restockRequestMap["status"] = "open"
+
+		restockRequestAsset, err := assets.NewAsset(restockRequestMap)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to create a new asset")
+		}
+
+		_, err = restockRequestAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Error saving asset on blockchain")
+		}
+
+		restockRequestJSON, nerr := json.Marshal(restockRequestAsset)
+		if nerr != nil {
+			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
+		}
+
+		// Marshal message to be logged
+		sourceChannel, _ := req["sourceChannel"].(string)
+		distributionPointId, _ := req["distributionPointId"].(string)
+		eventPayload, err := eventtypes.NewEventPayload(stub, "restockRequestedLog", restockRequestKey.Key(), fmt.Sprintf("Restock of %s requested for distribution point %s", category.Label(), distributionPointId))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.RestockRequestedPayload{
+			EventPayload:        eventPayload,
+			RequestID:           requestId,
+			SourceChannel:       sourceChannel,
+			DistributionPointID: distributionPointId,
+			Category:            category,
+			SuggestedQuantity:   toInt(req["suggestedQuantity"]),
+			Unit:                unit,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "restockRequestedLog", logMsg)
+
+		return restockRequestJSON, nil
+	},
+}
@@ -0,0 +1,221 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var RecordDelivery = tx.Transaction{
+	Tag:         "recordDelivery",
+	Label:       "Record Delivery",
+	Description: "Record the delivery of ration batches against a purchase order, closing it once fully delivered",
+	Method:      "POST",
+	Callers: []accesscontrol.Caller{ // Only org2 admin can call this transaction
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "purchaseOrder",
+			Label:       "Purchase Order",
+			Description: "Purchase Order",
+			DataType:    "->purchaseOrder",
+			Required:    true,
+		},
+		{
+			Tag:         "rations",
+			Label:       "Rations",
+			Description: "Ration batches created against the purchase order and delivered",
+			DataType:    "[]->ration",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		purchaseOrderKey, ok := req["purchaseOrder"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter purchaseOrder must be an asset")
+		}
+		rations, _ := req["rations"].([]interface{})
+		if len(rations) == 0 {
+			return nil, errors.NewCCError("a delivery must have at least one ration", http.StatusBadRequest)
+		}
+
+		purchaseOrderMap, err := purchaseOrderKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get purchase order from the ledger", err.Status())
+		}
+		status, _ := purchaseOrderMap["status"].(string)
+		if !datatypes.PurchaseOrderStatus(status).AcceptsDeliveries() {
+			return nil, errors.NewCCError("purchase order is not open for deliveries", http.StatusBadRequest)
+		}
+		orderId, _ := purchaseOrderMap["orderId"].(string)
+
+		lines, err := getPurchaseOrderLines(purchaseOrderMap)
+		if err != nil {
+			return nil, err
+		}
+
+		// Add the delivered rations to the order line of their category
+		rationKeys := []assets.Key{}
+		rationIds := []string{}
+		listed := map[string]bool{}
+		for _, ration := range rations {
+			rationKey, _ := ration.(assets.Key)
+			if listed[rationKey.Key()] {
+				return nil, errors.NewCCError(fmt.Sprintf("ration %s is listed more than once", rationKey.Key()), http.StatusBadRequest)
+			}
+			listed[rationKey.Key()] = true
+
+			rationMap, err := rationKey.GetMap(stub)
+			if err != nil {
+				return nil, errors.WrapErrorWithStatus(err, "failed to get ration from the ledger", err.Status())
+			}
+			rationId, _ := rationMap["id"].(string)
+			if referenceKey(rationMap["purchaseOrder"]) != purchaseOrderKey.Key() {
+				return nil, errors.NewCCError(fmt.Sprintf("ration %s was not created against purchase order %s", rationId, orderId), http.StatusBadRequest)
+			}
+			if rationMap["delivery"] != nil {
+				return nil, errors.NewCCError(fmt.Sprintf("ration %s is already delivered", rationId), http.StatusConflict)
+			}
+
+			category, amount, err := rationAmount(rationMap)
+			if err != nil {
+				return nil, err
+			}
+			delivered := false
+			for i := range lines {
+				if lines[i].Category == category {
+					lines[i].Delivered += float64(amount)
+					if lines[i].Delivered > lines[i].Quantity {
+						return nil, errors.NewCCError(fmt.Sprintf("delivery exceeds the ordered quantity of %s", lines[i].Category.Label()), http.StatusBadRequest)
+					}
+					delivered = true
+					break
+				}
+			}
+			if !delivered {
+				return nil, errors.NewCCError(fmt.Sprintf("category of ration %s is not on the purchase order", rationId), http.StatusBadRequest)
+			}
+
+			rationKeys = append(rationKeys, rationKey)
+			rationIds = append(rationIds, rationId)
+		}
+
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+
+		// Record the delivery and link the rations to it
+		deliveryId := stub.Stub.GetTxID()
+		deliveryAsset, err := assets.NewAsset(map[string]interface{}{
+			"@assetType":    "delivery",
+			"deliveryId":    deliveryId,
+			"purchaseOrder": purchaseOrderKey,
+			"rations":       rations,
+			"deliveryDate":  txTimestamp.AsTime(),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to create a new asset")
+		}
+		_, err = deliveryAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Error saving asset on blockchain")
+		}
+
+		deliveryKey, err := assets.NewKey(map[string]interface{}{
+			"@assetType": "delivery",
+			"@key":       deliveryAsset.Key(),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to get delivery key")
+		}
+		for _, rationKey := range rationKeys {
+			_, err = rationKey.Update(stub, map[string]interface{}{
+				"delivery": deliveryKey,
+			})
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to update ration")
+			}
+		}
+
+		// Close the order once every line is fully delivered
+		orderStatus := datatypes.PurchaseOrderStatusClosed
+		for _, line := range lines {
+			if line.Delivered < line.Quantity {
+				orderStatus = datatypes.PurchaseOrderStatusPartiallyDelivered
+				break
+			}
+		}
+
+		update := map[string]interface{}{
+			"lineItems": lines,
+			"status":    orderStatus,
+		}
+		if orderStatus == datatypes.PurchaseOrderStatusClosed {
+			update["closedDate"] = txTimestamp.AsTime()
+
+			// The restock requests answered by the order are fulfilled
+			restockRequests, _ := purchaseOrderMap["restockRequests"].([]interface{})
+			for _, restockRequest := range restockRequests {
+				restockRequestKey, err := assets.NewKey(restockRequest.(map[string]interface{}))
+				if err != nil {
+					return nil, errors.WrapError(err, "failed to get restock request key")
+				}
+				_, err = restockRequestKey.Update(stub, map[string]interface{}{
+					"status": "closed",
+				})
+				if err != nil {
+					return nil, errors.WrapError(err, "failed to update restock request")
+				}
+			}
+		}
+
+		_, err = purchaseOrderKey.Update(stub, update)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to update purchase order")
+		}
+
+		deliveryJSON, nerr := json.Marshal(deliveryAsset)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "deliveryRecordedLog", purchaseOrderKey.Key(), fmt.Sprintf("Delivery recorded for purchase order %s", orderId))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.DeliveryRecordedPayload{
+			EventPayload: eventPayload,
+			DeliveryID:   deliveryId,
+			OrderID:      orderId,
+			RationIDs:    rationIds,
+			LineItems:    lines,
+			OrderStatus:  orderStatus,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
+		}
+
+		events.CallEvent(stub, "deliveryRecordedLog", logMsg)
+
+		return deliveryJSON, nil
+	},
+}
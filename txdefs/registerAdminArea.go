@@ -0,0 +1,141 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var RegisterAdminArea = tx.Transaction{
+	Tag:         "registerAdminArea",
+	Label:       "Register Administrative Area",
+	Description: "Add a division, district, upazila or union to the administrative area reference table",
+	Method:      "POST",
+	Callers: []accesscontrol.Caller{ // Only orgMSP admin can call this transaction
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "name",
+			Label:       "Name",
+			Description: "Name of the area, as written in addresses",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "level",
+			Label:       "Level",
+			Description: "Level of the area",
+			DataType:    "adminAreaLevel",
+			Required:    true,
+		},
+		{
+			Tag:         "parent",
+			Label:       "Parent Area",
+			Description: "Area of the level above enclosing this one, omitted for divisions",
+			DataType:    "->adminArea",
+			Required:    false,
+		},
+		{
+			Tag:         "geoCode",
+			Label:       "Geocode",
+			Description: "Geocode of the area",
+			DataType:    "string",
+			Required:    false,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		name, _ := req["name"].(string)
+		level, _ := req["level"].(datatypes.AdminAreaLevel)
+		geoCode, _ := req["geoCode"].(string)
+		parentKey, hasParent := req["parent"].(assets.Key)
+
+		name = strings.TrimSpace(name)
+		if name == "" || strings.Contains(name, adminAreaPathSeparator) {
+			return nil, errors.NewCCError(fmt.Sprintf("name must not be empty nor contain '%s'", adminAreaPathSeparator), http.StatusBadRequest)
+		}
+
+		path := name
+		areaMap := map[string]interface{}{
+			"@assetType": "adminArea",
+			"name":       name,
+			"level":      level,
+			"geoCode":    geoCode,
+		}
+		if level == datatypes.AdminAreaLevelDivision {
+			if hasParent {
+				return nil, errors.NewCCError("divisions have no parent area", http.StatusBadRequest)
+			}
+		} else {
+			if !hasParent {
+				return nil, errors.NewCCError(fmt.Sprintf("%s areas must be registered under a parent area", level.AddressField()), http.StatusBadRequest)
+			}
+			parentMap, err := parentKey.GetMap(stub)
+			if err != nil {
+				return nil, errors.WrapErrorWithStatus(err, "failed to get parent area from the ledger", err.Status())
+			}
+			parentLevel := datatypes.AdminAreaLevel(toInt(parentMap["level"]))
+			if parentLevel != level-1 {
+				return nil, errors.NewCCError(fmt.Sprintf("%s areas must be registered under %s areas", level.AddressField(), (level-1).AddressField()), http.StatusBadRequest)
+			}
+			parentPath, _ := parentMap["path"].(string)
+			path = parentPath + adminAreaPathSeparator + name
+			areaMap["parent"] = parentKey
+		}
+		areaMap["path"] = path
+
+		areaAsset, err := assets.NewAsset(areaMap)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to create a new asset")
+		}
+
+		exists, err := areaAsset.ExistsInLedger(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to check administrative area")
+		}
+		if exists {
+			return nil, errors.NewCCError(fmt.Sprintf("administrative area %s is already registered", path), http.StatusConflict)
+		}
+
+		areaAssetMap, err := areaAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Error saving asset on blockchain")
+		}
+
+		areaJSON, nerr := json.Marshal(areaAssetMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "adminAreaRegisteredLog", areaAsset.Key(), fmt.Sprintf("Administrative area registered: %s", path))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.AdminAreaRegisteredPayload{
+			EventPayload: eventPayload,
+			Path:         path,
+			Level:        level.AddressField(),
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "adminAreaRegisteredLog", logMsg)
+
+		return areaJSON, nil
+	},
+}
@@ -0,0 +1,124 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var RegisterOfflineDevice = tx.Transaction{
+	Tag:         "registerOfflineDevice",
+	Label:       "Register Offline Device",
+	Description: "Register the device a distribution point records distributions with while offline",
+	Method:      "POST",
+	Callers: []accesscontrol.Caller{ // Only org1 admin can call this transaction
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "deviceId",
+			Label:       "Device ID",
+			Description: "Device ID",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "distributionPoint",
+			Label:       "Distribution Point",
+			Description: "Distribution point the device records distributions of",
+			DataType:    "->distributionPoint",
+			Required:    true,
+		},
+		{
+			Tag:         "publicKey",
+			Label:       "Public Key",
+			Description: "Base64 Ed25519 public key the device signs its batches with",
+			DataType:    "string",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		deviceId, _ := req["deviceId"].(string)
+		publicKey, _ := req["publicKey"].(string)
+		distributionPointKey, ok := req["distributionPoint"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter distributionPoint must be an asset")
+		}
+
+		distributionPointMap, err := distributionPointKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get distribution point from the ledger", err.Status())
+		}
+		distributionPointId, _ := distributionPointMap["distributionPointId"].(string)
+
+		// Only operators assigned to the distribution point can act on it
+		if err := checkOperatorAssignment(stub, distributionPointId); err != nil {
+			return nil, err
+		}
+
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+		deviceAsset, err := assets.NewAsset(map[string]interface{}{
+			"@assetType":        "offlineDevice",
+			"deviceId":          deviceId,
+			"distributionPoint": distributionPointKey,
+			"publicKey":         publicKey,
+			"lastSequence":      0,
+			"registeredDate":    txTimestamp.AsTime(),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build offline device")
+		}
+		exists, err := deviceAsset.ExistsInLedger(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to check offline device existence")
+		}
+		if exists {
+			return nil, errors.NewCCError(fmt.Sprintf("device %s is already registered", deviceId), http.StatusConflict)
+		}
+		deviceMap, err := deviceAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to register offline device")
+		}
+
+		deviceJSON, nerr := json.Marshal(deviceMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "offlineDeviceRegisteredLog", deviceAsset.Key(), fmt.Sprintf("Device %s registered for distribution point %s", deviceId, distributionPointId))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.OfflineDeviceRegisteredPayload{
+			EventPayload:        eventPayload,
+			DeviceID:            deviceId,
+			DistributionPointID: distributionPointId,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "offlineDeviceRegisteredLog", logMsg)
+
+		return deviceJSON, nil
+	},
+}
@@ -0,0 +1,144 @@
+package txdefs
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+)
+
+// claimSale is a sale of a reimbursement claim checked against the ledger
+type claimSale struct {
+	Key  string
+	Sale map[string]interface{}
+
+	// Expected is the subsidy of the sale as computed from its price schedule, which may
+	// differ from the subsidy recorded on the sale if either was tampered with
+	Expected datatypes.Money
+	Recorded datatypes.Money
+
+	// Disputed tells the sale cannot be paid: it is not the distributor's, the month's or
+	// the claim's, or it has no price schedule to pay it with
+	Disputed bool
+}
+
+// getClaim reads a reimbursement claim and checks it is in one of the given statuses
+func getClaim(stub *sw.StubWrapper, claimKey assets.Key, statuses ...datatypes.ReimbursementClaimStatus) (map[string]interface{}, errors.ICCError) {
+	claimMap, err := claimKey.GetMap(stub)
+	if err != nil {
+		return nil, errors.WrapErrorWithStatus(err, "failed to get reimbursement claim from the ledger", err.Status())
+	}
+
+	status, _ := claimMap["status"].(string)
+	for _, allowed := range statuses {
+		if datatypes.ReimbursementClaimStatus(status) == allowed {
+			return claimMap, nil
+		}
+	}
+	return nil, errors.NewCCError(fmt.Sprintf("reimbursement claim is %s", status), http.StatusBadRequest)
+}
+
+// claimDistributorId returns the ID of the distributor of a claim
+func claimDistributorId(stub *sw.StubWrapper, claimMap map[string]interface{}) (string, errors.ICCError) {
+	distributorKey, err := assets.NewKey(map[string]interface{}{"@assetType": "distributor", "@key": referenceKey(claimMap["distributor"])})
+	if err != nil {
+		return "", errors.WrapError(err, "failed to build distributor key")
+	}
+	distributorMap, err := distributorKey.GetMap(stub)
+	if err != nil {
+		return "", errors.WrapErrorWithStatus(err, "failed to get distributor from the ledger", err.Status())
+	}
+	distributorId, _ := distributorMap["distributorId"].(string)
+	return distributorId, nil
+}
+
+// checkClaimSales reads the sales of a claim and lists the discrepancies between the claim,
+// its sales and their price schedules
+func checkClaimSales(stub *sw.StubWrapper, claimMap map[string]interface{}) ([]claimSale, []string, errors.ICCError) {
+	claimKey, _ := claimMap["@key"].(string)
+	distributor := referenceKey(claimMap["distributor"])
+	month, _ := claimMap["month"].(string)
+
+	sales := []claimSale{}
+	findings := []string{}
+	seen := map[string]bool{}
+	total := datatypes.Money(0)
+	saleRefs, _ := claimMap["sales"].([]interface{})
+	for _, saleRef := range saleRefs {
+		saleKey := referenceKey(saleRef)
+		if seen[saleKey] {
+			findings = append(findings, fmt.Sprintf("sale %s is listed more than once", saleKey))
+			continue
+		}
+		seen[saleKey] = true
+
+		key, err := assets.NewKey(map[string]interface{}{"@assetType": "rationSale", "@key": saleKey})
+		if err != nil {
+			return nil, nil, errors.WrapError(err, "failed to build ration sale key")
+		}
+		saleMap, err := key.GetMap(stub)
+		if err != nil {
+			findings = append(findings, fmt.Sprintf("sale %s is not on the ledger", saleKey))
+			continue
+		}
+		sale := claimSale{Key: saleKey, Sale: saleMap}
+
+		if referenceKey(saleMap["distributor"]) != distributor {
+			findings = append(findings, fmt.Sprintf("sale %s was not made by the claiming distributor", saleKey))
+			sale.Disputed = true
+		}
+		if saleClaimMonth(saleMap) != month {
+			findings = append(findings, fmt.Sprintf("sale %s is claimed in %s, not %s", saleKey, saleClaimMonth(saleMap), month))
+			sale.Disputed = true
+		}
+		if referenceKey(saleMap["claim"]) != claimKey {
+			findings = append(findings, fmt.Sprintf("sale %s belongs to another claim", saleKey))
+			sale.Disputed = true
+		}
+
+		sale.Recorded, err = datatypes.MoneyOf(saleMap["subsidyClaimed"])
+		if err != nil {
+			return nil, nil, errors.WrapErrorWithStatus(err, "invalid subsidy claimed", err.Status())
+		}
+		total += sale.Recorded
+
+		scheduleKey, err := assets.NewKey(map[string]interface{}{"@assetType": "priceSchedule", "@key": referenceKey(saleMap["priceSchedule"])})
+		if err != nil {
+			return nil, nil, errors.WrapError(err, "failed to build price schedule key")
+		}
+		schedule, err := scheduleKey.GetMap(stub)
+		if err != nil {
+			findings = append(findings, fmt.Sprintf("price schedule of sale %s is not on the ledger", saleKey))
+			sale.Disputed = true
+			sales = append(sales, sale)
+			continue
+		}
+		category := datatypes.RationCategory(toInt(saleMap["category"]))
+		if datatypes.RationCategory(toInt(schedule["category"])) != category {
+			findings = append(findings, fmt.Sprintf("sale %s is priced with the schedule of another category", saleKey))
+			sale.Disputed = true
+		}
+		_, sale.Expected, err = salePrice(schedule, category, toInt(saleMap["quantity"]))
+		if err != nil {
+			return nil, nil, err
+		}
+		if sale.Expected != sale.Recorded {
+			findings = append(findings, fmt.Sprintf("sale %s claims %s of subsidy, its price schedule gives %s", saleKey, sale.Recorded, sale.Expected))
+		}
+
+		sales = append(sales, sale)
+	}
+
+	amountClaimed, err := datatypes.MoneyOf(claimMap["amountClaimed"])
+	if err != nil {
+		return nil, nil, errors.WrapErrorWithStatus(err, "invalid amount claimed", err.Status())
+	}
+	if amountClaimed != total {
+		findings = append(findings, fmt.Sprintf("claim amounts to %s, its sales to %s", amountClaimed, total))
+	}
+
+	return sales, findings, nil
+}
@@ -0,0 +1,113 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// RemoveNominee ends the authorization of a nominee before its validity period is over.
+// The nominee is kept on the ledger, as past distributions reference it.
+var RemoveNominee = tx.Transaction{
+	Tag:         "removeNominee",
+	Label:       "Remove Nominee",
+	Description: "End the authorization of a nominee to collect the rations of a member",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{ // Only org1 admin can call this transaction
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "nominee",
+			Label:       "Nominee",
+			Description: "Nominee",
+			DataType:    "->nominee",
+			Required:    true,
+		},
+		{
+			Tag:         "reason",
+			Label:       "Reason",
+			Description: "Reason of the removal",
+			DataType:    "string",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		nomineeKey, ok := req["nominee"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter nominee must be an asset")
+		}
+		reason, _ := req["reason"].(string)
+
+		nomineeMap, err := nomineeKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get nominee from the ledger", err.Status())
+		}
+		if removed, _ := nomineeMap["removed"].(bool); removed {
+			return nil, errors.NewCCError("nominee is already removed", http.StatusConflict)
+		}
+		nid, _ := nomineeMap["nid"].(string)
+
+		memberKey, err := assets.NewKey(map[string]interface{}{"@assetType": "member", "@key": referenceKey(nomineeMap["principal"])})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build member key")
+		}
+		memberMap, err := memberKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get member from the ledger", err.Status())
+		}
+		principalNid, _ := memberMap["nid"].(string)
+
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+
+		updatedNomineeMap, err := nomineeKey.Update(stub, map[string]interface{}{
+			"removed":       true,
+			"removalReason": reason,
+			"removedDate":   txTimestamp.AsTime(),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to update nominee")
+		}
+
+		updatedNomineeJSON, nerr := json.Marshal(updatedNomineeMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "nomineeRemovedLog", nomineeKey.Key(), fmt.Sprintf("Nominee %s removed for member %s", nid, principalNid))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.NomineeRemovedPayload{
+			EventPayload: eventPayload,
+			PrincipalNID: principalNid,
+			NomineeNID:   nid,
+			Reason:       reason,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "nomineeRemovedLog", logMsg)
+
+		return updatedNomineeJSON, nil
+	},
+}
@@ -3,8 +3,9 @@ package txdefs
 import (
 	"encoding/json"
 	"fmt"
-	"sync"
+	"net/http"
 
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
 	"github.com/hyperledger-labs/cc-tools/accesscontrol"
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
@@ -13,7 +14,9 @@ import (
 	tx "github.com/hyperledger-labs/cc-tools/transactions"
 )
 
-var ReplenishInventory = tx.Transaction{
+// ReplenishInventory adds delivered rations to the stock of a distribution point. Once it has
+// an approval policy, replenishments of at least its minimum size must be approved.
+var ReplenishInventory = requireApproval(tx.Transaction{
 	Tag:         "replenishInventory",
 	Label:       "Replenish Inventory",
 	Description: "Replenish the inventory of a distribution point",
@@ -49,100 +52,120 @@ var ReplenishInventory = tx.Transaction{
 		rations, _ := req["rations"].([]interface{})
 
 		// Retrieve the distribution point asset
-		distributionPointKey, ok := req["distributionPointId"].(assets.Key)
-		if !ok {
-			return nil, errors.WrapError(nil, "failed to get distribution point ID from the request")
+		distributionPointKey, err := assets.NewKey(map[string]interface{}{
+			"@assetType":          "distributionPoint",
+			"distributionPointId": distributionPointId,
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to get distribution point key")
 		}
-
-		distributionPointAsset, err := distributionPointKey.Get(stub)
+		exists, err := distributionPointKey.ExistsInLedger(stub)
 		if err != nil {
-			return nil, errors.WrapError(err, "failed to get distribution point asset from the ledger")
+			return nil, errors.WrapError(err, "failed to check distribution point existence")
 		}
-
-		// Update the distribution point asset with the new rations
-		distributionPointMap := (map[string]interface{})(*distributionPointAsset)
-		if distributionPointMap["inventory"] == nil {
-			distributionPointMap["inventory"] = make(map[string]interface{})
+		if !exists {
+			return nil, errors.NewCCError("distribution point not found", http.StatusNotFound)
 		}
-		inventoryMap := distributionPointMap["inventory"].(map[string]interface{})
 
-		for _, ration := range rations {
-			rationMap := ration.(map[string]interface{})
-			rationId := rationMap["id"].(string)
-			inventoryMap[rationId] = ration
+		// Only operators assigned to the distribution point can act on it
+		if err := checkOperatorAssignment(stub, distributionPointId); err != nil {
+			return nil, err
 		}
 
-		// Concurrently update ration assets
-		var wg sync.WaitGroup
-		var mutex sync.Mutex
-		var updateErr error
-
+		// Add the rations to the distribution point stock of their category. A ration is stocked
+		// once, at a single point, after it has been delivered.
+		rationIds := []string{}
+		stocks := []map[string]interface{}{}
+		listed := map[string]bool{}
 		for _, ration := range rations {
-			wg.Add(1)
-			go func(ration interface{}) {
-				defer wg.Done()
-
-				rationKey, ok := ration.(assets.Key)
-				if !ok {
-					mutex.Lock()
-					updateErr = errors.WrapError(nil, "failed to get ration ID from the request")
-					mutex.Unlock()
-					return
-				}
-
-				rationAsset, err := rationKey.Get(stub)
-				if err != nil {
-					mutex.Lock()
-					updateErr = errors.WrapError(err, "failed to get ration asset from the ledger")
-					mutex.Unlock()
-					return
-				}
-
-				rationMap := (map[string]interface{})(*rationAsset)
-				rationMap["quantity"] = ration.(map[string]interface{})["quantity"]
-				rationMap["expiryDate"] = ration.(map[string]interface{})["expiryDate"]
-				rationMap["mfgDate"] = ration.(map[string]interface{})["mfgDate"]
-				rationMap["batchNumber"] = ration.(map[string]interface{})["batchNumber"]
-				rationMap["description"] = ration.(map[string]interface{})["description"]
-				rationMap["package"] = ration.(map[string]interface{})["package"]
-				rationMap["distributedBy"] = ration.(map[string]interface{})["distributedBy"]
-
-				_, err = rationAsset.Update(stub, rationMap)
-				if err != nil {
-					mutex.Lock()
-					updateErr = errors.WrapError(err, "failed to update ration asset")
-					mutex.Unlock()
-					return
-				}
-			}(ration)
-		}
-
-		wg.Wait()
-
-		if updateErr != nil {
-			return nil, errors.WrapError(updateErr, "failed to update ration asset")
-		}
-		distributionPointMap["inventory"] = inventoryMap
-
-		updatedDistributionPointAsset, err := distributionPointAsset.Update(stub, distributionPointMap)
-		if err != nil {
-			return nil, errors.WrapError(err, "failed to update distribution point asset")
+			rationKey, ok := ration.(assets.Key)
+			if !ok {
+				return nil, errors.WrapError(nil, "failed to get ration from the request")
+			}
+			if listed[rationKey.Key()] {
+				return nil, errors.NewCCError(fmt.Sprintf("ration %s is listed more than once", rationKey.Key()), http.StatusBadRequest)
+			}
+			listed[rationKey.Key()] = true
+
+			rationMap, err := rationKey.GetMap(stub)
+			if err != nil {
+				return nil, errors.WrapErrorWithStatus(err, "failed to get ration asset from the ledger", err.Status())
+			}
+			rationId, _ := rationMap["id"].(string)
+			if rationMap["delivery"] == nil {
+				return nil, errors.NewCCError(fmt.Sprintf("ration %s is not delivered yet", rationId), http.StatusBadRequest)
+			}
+			if archived, _ := rationMap["archived"].(bool); archived {
+				return nil, errors.NewCCError(fmt.Sprintf("ration %s is archived", rationId), http.StatusConflict)
+			}
+			if rationMap["stockedAt"] != nil {
+				return nil, errors.NewCCError(fmt.Sprintf("ration %s is already stocked", rationId), http.StatusConflict)
+			}
+			category, amount, err := rationAmount(rationMap)
+			if err != nil {
+				return nil, err
+			}
+
+			stock, err := adjustStock(stub, distributionPointKey, distributionPointId, category, amount)
+			if err != nil {
+				return nil, err
+			}
+			_, err = rationKey.Update(stub, map[string]interface{}{
+				"stockedAt": distributionPointKey,
+			})
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to update ration")
+			}
+
+			rationIds = append(rationIds, rationId)
+			stocks = append(stocks, stock.Stock)
 		}
 
-		// Marshal asset back to JSON format
-		updatedDistributionPointJSON, nerr := json.Marshal(updatedDistributionPointAsset)
+		// Marshal stocks to JSON format
+		stocksJSON, nerr := json.Marshal(stocks)
 		if nerr != nil {
-			return nil, errors.WrapError(err, "failed to marshal response")
+			return nil, errors.WrapError(nerr, "failed to marshal response")
 		}
 
 		// Marshal message to be logged
-		logMsg, erre := json.Marshal(fmt.Sprintf("Inventory replenished for distribution point with ID: %s", distributionPointId))
+		eventPayload, err := eventtypes.NewEventPayload(stub, "inventoryReplenishedLog", distributionPointKey.Key(), fmt.Sprintf("Inventory replenished for distribution point with ID: %s", distributionPointId))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, erre := json.Marshal(eventtypes.InventoryReplenishedPayload{
+			EventPayload:        eventPayload,
+			DistributionPointID: distributionPointId,
+			RationIDs:           rationIds,
+		})
 		if erre != nil {
 			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
 		}
 
 		events.CallEvent(stub, "inventoryReplenishedLog", logMsg)
 
-		return updatedDistributionPointJSON, nil
+		return stocksJSON, nil
 	},
+})
+
+// replenishmentSize measures a replenishment for its approval policy, as the sum of the base
+// amounts (grams or millilitres) of its rations
+func replenishmentSize(stub *sw.StubWrapper, req map[string]interface{}) (int, errors.ICCError) {
+	rations, _ := req["rations"].([]interface{})
+	size := 0
+	for _, ration := range rations {
+		rationKey, ok := ration.(assets.Key)
+		if !ok {
+			return 0, errors.WrapError(nil, "failed to get ration from the request")
+		}
+		rationMap, err := rationKey.GetMap(stub)
+		if err != nil {
+			return 0, errors.WrapErrorWithStatus(err, "failed to get ration asset from the ledger", err.Status())
+		}
+		_, amount, err := rationAmount(rationMap)
+		if err != nil {
+			return 0, err
+		}
+		size += amount
+	}
+	return size, nil
 }
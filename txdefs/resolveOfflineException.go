@@ -0,0 +1,117 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// ResolveOfflineException closes the review of an offline distribution conflicting with the
+// ledger. The ledger is left as is: the resolution records what the supervisor decided.
+var ResolveOfflineException = tx.Transaction{
+	Tag:         "resolveOfflineException",
+	Label:       "Resolve Offline Exception",
+	Description: "Record the review of an offline distribution conflicting with the ledger",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{ // Only org2 admin can call this transaction
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "exception",
+			Label:       "Offline Exception",
+			Description: "Offline Exception",
+			DataType:    "->offlineException",
+			Required:    true,
+		},
+		{
+			Tag:         "resolution",
+			Label:       "Resolution",
+			Description: "What was found and decided",
+			DataType:    "string",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		exceptionKey, ok := req["exception"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter exception must be an asset")
+		}
+		resolution, _ := req["resolution"].(string)
+		resolution = strings.TrimSpace(resolution)
+		if resolution == "" {
+			return nil, errors.NewCCError("resolution is required", http.StatusBadRequest)
+		}
+
+		exceptionMap, err := exceptionKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get offline exception from the ledger", err.Status())
+		}
+		if resolved, _ := exceptionMap["resolved"].(bool); resolved {
+			return nil, errors.NewCCError("offline exception is already resolved", http.StatusConflict)
+		}
+		exceptionId, _ := exceptionMap["exceptionId"].(string)
+		reason, _ := exceptionMap["reason"].(string)
+
+		resolvedBy, err := stub.GetMSPID()
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to get caller MSP")
+		}
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+
+		updatedExceptionMap, err := exceptionKey.Update(stub, map[string]interface{}{
+			"resolved":     true,
+			"resolution":   resolution,
+			"resolvedBy":   resolvedBy,
+			"resolvedDate": txTimestamp.AsTime(),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to update offline exception")
+		}
+
+		updatedExceptionJSON, nerr := json.Marshal(updatedExceptionMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "offlineExceptionResolvedLog", exceptionKey.Key(), fmt.Sprintf("Offline exception %s resolved", exceptionId))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.OfflineExceptionResolvedPayload{
+			EventPayload: eventPayload,
+			ExceptionID:  exceptionId,
+			Reason:       datatypes.OfflineExceptionReason(reason),
+			Resolution:   resolution,
+			ResolvedBy:   resolvedBy,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "offlineExceptionResolvedLog", logMsg)
+
+		return updatedExceptionJSON, nil
+	},
+}
@@ -0,0 +1,42 @@
+package txdefs
+
+import (
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var RestoreMember = tx.Transaction{
+	Tag:         "restoreMember",
+	Label:       "Restore Member",
+	Description: "Restore an archived member",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{ // Only org1 admin can call this transaction
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "member",
+			Label:       "Member",
+			Description: "Member to be restored",
+			DataType:    "->member",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		memberKey, ok := req["member"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter member must be an asset")
+		}
+		return setArchived(stub, memberKey, false, "")
+	},
+}
@@ -0,0 +1,42 @@
+package txdefs
+
+import (
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var RestoreRation = tx.Transaction{
+	Tag:         "restoreRation",
+	Label:       "Restore Ration",
+	Description: "Restore an archived ration",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{ // Only org2 admin can call this transaction
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "ration",
+			Label:       "Ration",
+			Description: "Ration to be restored",
+			DataType:    "->ration",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		rationKey, ok := req["ration"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter ration must be an asset")
+		}
+		return setArchived(stub, rationKey, false, "")
+	},
+}
@@ -0,0 +1,134 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// RotateIssuerKey publishes the new key the calling org signs ration card tokens with and
+// retires its previous one. The first rotation publishes the org's initial key.
+var RotateIssuerKey = tx.Transaction{
+	Tag:         "rotateIssuerKey",
+	Label:       "Rotate Issuer Key",
+	Description: "Publish a new key to sign ration card tokens with, retiring the previous one",
+	Method:      "POST",
+	Callers: []accesscontrol.Caller{ // Only org3 admin can call this transaction
+		{
+			MSP: "org3MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "keyId",
+			Label:       "Key ID",
+			Description: "Identifier of the new key, printed in the tokens it signs",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "publicKey",
+			Label:       "Public Key",
+			Description: "Base64 Ed25519 public key",
+			DataType:    "string",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		keyId, _ := req["keyId"].(string)
+		publicKey, _ := req["publicKey"].(string)
+
+		issuer, err := stub.GetMSPID()
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to get caller MSP")
+		}
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+
+		keyAsset, err := assets.NewAsset(map[string]interface{}{
+			"@assetType":    "issuerKey",
+			"keyId":         keyId,
+			"issuer":        issuer,
+			"publicKey":     publicKey,
+			"status":        datatypes.IssuerKeyStatusActive,
+			"activatedDate": txTimestamp.AsTime(),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build issuer key")
+		}
+		exists, err := keyAsset.ExistsInLedger(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to check issuer key existence")
+		}
+		if exists {
+			return nil, errors.NewCCError(fmt.Sprintf("issuer key %s already exists", keyId), http.StatusConflict)
+		}
+
+		activeKeys, err := activeIssuerKeys(stub, issuer)
+		if err != nil {
+			return nil, err
+		}
+		retiredKeys := []string{}
+		for _, activeKey := range activeKeys {
+			key, err := assets.NewKey(activeKey)
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to build issuer key key")
+			}
+			_, err = key.Update(stub, map[string]interface{}{
+				"status":      datatypes.IssuerKeyStatusRetired,
+				"retiredDate": txTimestamp.AsTime(),
+			})
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to retire issuer key")
+			}
+			retiredKeyId, _ := activeKey["keyId"].(string)
+			retiredKeys = append(retiredKeys, retiredKeyId)
+		}
+
+		keyMap, err := keyAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to publish issuer key")
+		}
+
+		keyJSON, nerr := json.Marshal(keyMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "issuerKeyRotatedLog", keyAsset.Key(), fmt.Sprintf("Issuer %s signs card tokens with key %s", issuer, keyId))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.IssuerKeyRotatedPayload{
+			EventPayload: eventPayload,
+			Issuer:       issuer,
+			KeyID:        keyId,
+			PublicKey:    publicKey,
+			RetiredKeys:  retiredKeys,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "issuerKeyRotatedLog", logMsg)
+
+		return keyJSON, nil
+	},
+}
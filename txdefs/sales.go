@@ -0,0 +1,167 @@
+package txdefs
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+)
+
+// sale is a ration handed out to a member, priced by price and recorded by record
+type sale struct {
+	ID                  string
+	Member              map[string]interface{}
+	Ration              assets.Key
+	RationID            string
+	DistributionPoint   assets.Key
+	DistributionPointID string
+	Distributor         map[string]interface{} // Reference to the distributor running the point, if any
+	Category            datatypes.RationCategory
+	Amount              int // In the base unit of the category
+	Date                time.Time
+	CollectedBy         datatypes.Collector
+	Nominee             assets.Key // Nominee who collected the ration, if not the member
+	NomineeNID          string
+
+	Schedule       map[string]interface{}
+	AmountPaid     datatypes.Money
+	SubsidyClaimed datatypes.Money
+}
+
+// checkMonthlyPickup refuses the sale if the member already collected the ration category in
+// the month of the sale
+func (s *sale) checkMonthlyPickup(stub *sw.StubWrapper) errors.ICCError {
+	month := s.Date.Format(saleMonthLayout)
+	response, err := assets.Search(stub, map[string]interface{}{
+		"selector": map[string]interface{}{
+			"@assetType":  "rationSale",
+			"member.@key": s.Member["@key"],
+			"category":    s.Category,
+			"month":       month,
+		},
+		"limit": 1,
+	}, "", false)
+	if err != nil {
+		return errors.WrapErrorWithStatus(err, "failed to search ration sales", err.Status())
+	}
+	if len(response.Result) > 0 {
+		return errors.NewCCError(fmt.Sprintf("member already collected %s in %s", s.Category.Label(), month), http.StatusConflict)
+	}
+	return nil
+}
+
+// price prices the sale at the schedule in force on its date for the member's card category
+func (s *sale) price(stub *sw.StubWrapper, cardCategory datatypes.RationCardCategory) errors.ICCError {
+	schedule, err := priceScheduleAt(stub, s.Category, cardCategory, s.Date)
+	if err != nil {
+		return err
+	}
+	amountPaid, subsidyClaimed, err := salePrice(schedule, s.Category, s.Amount)
+	if err != nil {
+		return err
+	}
+
+	s.Schedule, s.AmountPaid, s.SubsidyClaimed = schedule, amountPaid, subsidyClaimed
+	return nil
+}
+
+// claimMonth returns the month whose reimbursement claim covers the sale. Sales replayed from
+// offline devices once their distributor claimed their month are left to the claim of the
+// month they reach the ledger.
+func (s *sale) claimMonth(stub *sw.StubWrapper) (string, errors.ICCError) {
+	month := s.Date.Format(saleMonthLayout)
+	if s.Distributor == nil {
+		return month, nil
+	}
+
+	claimKey, err := assets.NewKey(map[string]interface{}{
+		"@assetType":  "reimbursementClaim",
+		"distributor": s.Distributor,
+		"month":       month,
+	})
+	if err != nil {
+		return "", errors.WrapError(err, "failed to build reimbursement claim key")
+	}
+	claimed, err := claimKey.ExistsInLedger(stub)
+	if err != nil {
+		return "", errors.WrapError(err, "failed to check reimbursement claim existence")
+	}
+	if !claimed {
+		return month, nil
+	}
+
+	txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+	if nerr != nil {
+		return "", errors.WrapError(nerr, "failed to get transaction timestamp")
+	}
+	return txTimestamp.AsTime().Format(saleMonthLayout), nil
+}
+
+// saleClaimMonth returns the month whose reimbursement claim covers a recorded sale
+func saleClaimMonth(saleMap map[string]interface{}) string {
+	if claimMonth, ok := saleMap["claimMonth"].(string); ok && claimMonth != "" {
+		return claimMonth
+	}
+	month, _ := saleMap["month"].(string)
+	return month
+}
+
+// record puts the rationSale of a priced sale, the record of the distribution, and returns its
+// key and the sale
+func (s *sale) record(stub *sw.StubWrapper) (assets.Key, map[string]interface{}, errors.ICCError) {
+	memberKey, err := assets.NewKey(s.Member)
+	if err != nil {
+		return nil, nil, errors.WrapError(err, "failed to get member key")
+	}
+	scheduleKey, err := assets.NewKey(s.Schedule)
+	if err != nil {
+		return nil, nil, errors.WrapError(err, "failed to build price schedule key")
+	}
+	claimMonth, err := s.claimMonth(stub)
+	if err != nil {
+		return nil, nil, err
+	}
+	saleMap := map[string]interface{}{
+		"@assetType":        "rationSale",
+		"saleId":            s.ID,
+		"member":            memberKey,
+		"ration":            s.Ration,
+		"distributionPoint": s.DistributionPoint,
+		"priceSchedule":     scheduleKey,
+		"category":          s.Category,
+		"quantity":          s.Amount,
+		"unit":              s.Category.BaseUnit(),
+		"amountPaid":        s.AmountPaid,
+		"subsidyClaimed":    s.SubsidyClaimed,
+		"saleDate":          s.Date.UTC(),
+		"month":             s.Date.Format(saleMonthLayout),
+		"claimMonth":        claimMonth,
+	}
+	if s.Distributor != nil {
+		saleMap["distributor"] = s.Distributor
+	}
+	if s.CollectedBy != "" {
+		saleMap["collectedBy"] = s.CollectedBy
+	}
+	if s.Nominee != nil {
+		saleMap["nominee"] = s.Nominee
+	}
+	saleAsset, err := assets.NewAsset(saleMap)
+	if err != nil {
+		return nil, nil, errors.WrapError(err, "failed to build ration sale")
+	}
+	putSaleMap, err := saleAsset.PutNew(stub)
+	if err != nil {
+		return nil, nil, errors.WrapError(err, "failed to record ration sale")
+	}
+	saleKey, err := assets.NewKey(saleMap)
+	if err != nil {
+		return nil, nil, errors.WrapError(err, "failed to build ration sale key")
+	}
+
+	return saleKey, putSaleMap, nil
+}
@@ -0,0 +1,27 @@
+package txdefs
+
+import (
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// Search is the cc-tools search transaction leaving archived assets out of the results,
+// unless includeArchived is set. It takes precedence over the cc-tools one in the tx list.
+var Search = func() tx.Transaction {
+	t := tx.Search
+	t.Args = append(append(tx.ArgList{}, t.Args...), tx.Argument{
+		Tag:         "includeArchived",
+		Description: "Include archived members and rations in the results.",
+		DataType:    "boolean",
+	})
+	routine := t.Routine
+	t.Routine = func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		if includeArchived, _ := req["includeArchived"].(bool); !includeArchived {
+			query, _ := req["query"].(map[string]interface{})
+			excludeArchived(query)
+		}
+		return routine(stub, req)
+	}
+	return t
+}()
@@ -0,0 +1,130 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// SetApprovalPolicy is itself a high-impact transaction: once a transaction has an approval
+// policy, changing it must be approved as its current policy requires
+var SetApprovalPolicy = requireApproval(tx.Transaction{
+	Tag:         "setApprovalPolicy",
+	Label:       "Set Approval Policy",
+	Description: "Require M of N organizations to approve a high-impact transaction",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{ // Only orgMSP admin can call this transaction
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "txTag",
+			Label:       "Transaction Tag",
+			Description: "Tag of the high-impact transaction",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "approvers",
+			Label:       "Approvers",
+			Description: "MSP IDs of the organizations allowed to propose and approve",
+			DataType:    "[]string",
+			Required:    true,
+		},
+		{
+			Tag:         "threshold",
+			Label:       "Threshold",
+			Description: "Number of approvers required to execute the transaction",
+			DataType:    "integer",
+			Required:    true,
+		},
+		{
+			Tag:         "validityHours",
+			Label:       "Validity (hours)",
+			Description: "Hours after which an unexecuted proposal expires, defaults to 72",
+			DataType:    "integer",
+			Required:    false,
+		},
+		{
+			Tag:         "minimumSize",
+			Label:       "Minimum Size",
+			Description: "Size under which calls run without approval, for transactions measuring their calls",
+			DataType:    "integer",
+			Required:    false,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		txTag, _ := req["txTag"].(string)
+		approvers := toStrings(req["approvers"])
+		threshold := toInt(req["threshold"])
+		validityHours := 72
+		if _, ok := req["validityHours"]; ok {
+			validityHours = toInt(req["validityHours"])
+		}
+		minimumSize := toInt(req["minimumSize"])
+
+		if _, ok := multisigTxs[txTag]; !ok || policyTargets[txTag] != "" {
+			return nil, errors.NewCCError(fmt.Sprintf("transaction %s does not support multi-signature approval", txTag), http.StatusBadRequest)
+		}
+		if _, ok := approvalSizes[txTag]; !ok && minimumSize != 0 {
+			return nil, errors.NewCCError(fmt.Sprintf("calls of %s are not measured, they all require approval", txTag), http.StatusBadRequest)
+		}
+		if threshold < 1 || threshold > len(approvers) {
+			return nil, errors.NewCCError("threshold must be between 1 and the number of approvers", http.StatusBadRequest)
+		}
+
+		policyAsset, err := assets.NewAsset(map[string]interface{}{
+			"@assetType":    "approvalPolicy",
+			"txTag":         txTag,
+			"approvers":     approvers,
+			"threshold":     threshold,
+			"validityHours": validityHours,
+			"minimumSize":   minimumSize,
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to create a new asset")
+		}
+
+		policyMap, err := policyAsset.Put(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Error saving asset on blockchain")
+		}
+
+		policyJSON, nerr := json.Marshal(policyMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "approvalPolicySetLog", policyAsset.Key(), fmt.Sprintf("Approval policy set for %s: %d of %d", txTag, threshold, len(approvers)))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.ApprovalPolicySetPayload{
+			EventPayload:  eventPayload,
+			TxTag:         txTag,
+			Approvers:     approvers,
+			Threshold:     threshold,
+			ValidityHours: validityHours,
+			MinimumSize:   minimumSize,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
+		}
+
+		events.CallEvent(stub, "approvalPolicySetLog", logMsg)
+
+		return policyJSON, nil
+	},
+})
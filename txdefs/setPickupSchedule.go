@@ -3,7 +3,10 @@ package txdefs
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
 	"github.com/hyperledger-labs/cc-tools/accesscontrol"
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
@@ -45,25 +48,36 @@ var SetPickupSchedule = tx.Transaction{
 	},
 	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
 		distributionPointId, _ := req["distributionPointId"].(string)
-		pickupSchedule, _ := req["pickupSchedule"].(string)
+		schedule, _ := req["pickupSchedule"].(datatypes.RationPickupSchedule)
 
-		// Retrieve the distribution point asset
-		distributionPointKey, ok := req["distributionPointId"].(assets.Key)
-		if !ok {
-			return nil, errors.WrapError(nil, "failed to get distribution point ID from the request")
+		// Only operators assigned to the distribution point can act on it
+		if err := checkOperatorAssignment(stub, distributionPointId); err != nil {
+			return nil, err
 		}
 
-		distributionPointAsset, err := distributionPointKey.Get(stub)
+		// Retrieve the distribution point asset
+		distributionPointKey, err := assets.NewKey(map[string]interface{}{
+			"@assetType":          "distributionPoint",
+			"distributionPointId": distributionPointId,
+		})
 		if err != nil {
-			return nil, errors.WrapError(err, "failed to get distribution point asset from the ledger")
+			return nil, errors.WrapError(err, "failed to build distribution point key")
+		}
+		exists, err := distributionPointKey.ExistsInLedger(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to check distribution point existence")
+		}
+		if !exists {
+			return nil, errors.NewCCError(fmt.Sprintf("distribution point %s does not exist", distributionPointId), http.StatusNotFound)
 		}
 
-		// Check if the schedule is colliding with any other schedule
+		// Check if the schedule is colliding with the schedule of another distribution point
 		query := map[string]interface{}{
 			"selector": map[string]interface{}{
-				"@assetType":          "distributionPoint",
-				"pickupSchedule":      pickupSchedule,
-				"distributionPointId": distributionPointId,
+				"@assetType":                "distributionPoint",
+				"pickupSchedule.pickupDate": schedule.PickupDate,
+				"pickupSchedule.location":   schedule.Location,
+				"distributionPointId":       map[string]interface{}{"$ne": distributionPointId},
 			},
 		}
 
@@ -72,15 +86,18 @@ var SetPickupSchedule = tx.Transaction{
 			return nil, errors.WrapErrorWithStatus(err, "error searching for distribution point's pickup schedule", 500)
 		}
 
-		if response.Metadata.XXX_Size() > 0 {
+		if len(response.Result) > 0 {
 			return nil, errors.NewCCError("pickup schedule is colliding with another schedule", 400)
 		}
 
 		// Update the distribution point asset with the pickup schedule
-		distributionPointMap := (map[string]interface{})(*distributionPointAsset)
-		distributionPointMap["pickupSchedule"] = pickupSchedule
-
-		updatedDistributionPointAsset, err := distributionPointAsset.Update(stub, distributionPointMap)
+		scheduleJSON, nerr := json.Marshal(schedule)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode pickup schedule")
+		}
+		updatedDistributionPointAsset, err := distributionPointKey.Update(stub, map[string]interface{}{
+			"pickupSchedule": string(scheduleJSON),
+		})
 		if err != nil {
 			return nil, errors.WrapError(err, "failed to update distribution point asset")
 		}
@@ -88,11 +105,21 @@ var SetPickupSchedule = tx.Transaction{
 		// Marshal asset back to JSON format
 		updatedDistributionPointJSON, nerr := json.Marshal(updatedDistributionPointAsset)
 		if nerr != nil {
-			return nil, errors.WrapError(err, "failed to marshal response")
+			return nil, errors.WrapError(nerr, "failed to marshal response")
 		}
 
 		// Marshal message to be logged
-		logMsg, erre := json.Marshal(fmt.Sprintf("Pickup schedule set for distribution point: %s", distributionPointId))
+		eventPayload, err := eventtypes.NewEventPayload(stub, "pickupScheduleSetLog", distributionPointKey.Key(), fmt.Sprintf("Pickup schedule set for distribution point: %s", distributionPointId))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, erre := json.Marshal(eventtypes.PickupScheduleSetPayload{
+			EventPayload:        eventPayload,
+			DistributionPointID: distributionPointId,
+			PickupDate:          schedule.PickupDate,
+			RationType:          schedule.RationType,
+			Quantity:            schedule.Quantity,
+		})
 		if erre != nil {
 			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
 		}
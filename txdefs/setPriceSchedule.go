@@ -0,0 +1,180 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var SetPriceSchedule = requireApproval(tx.Transaction{
+	Tag:         "setPriceSchedule",
+	Label:       "Set Price Schedule",
+	Description: "Set the market and subsidized price of a ration category for a ration card category from a start date",
+	Method:      "POST",
+	Callers: []accesscontrol.Caller{ // Only org2 admin can call this transaction
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "category",
+			Label:       "Ration Category",
+			Description: "Ration Category",
+			DataType:    "rationCategory",
+			Required:    true,
+		},
+		{
+			Tag:         "cardCategory",
+			Label:       "Ration Card Category",
+			Description: "Ration card category the prices apply to",
+			DataType:    "rationCardCategory",
+			Required:    true,
+		},
+		{
+			Tag:         "startDate",
+			Label:       "Start Date",
+			Description: "Date the prices apply from",
+			DataType:    "datetime",
+			Required:    true,
+		},
+		{
+			Tag:         "endDate",
+			Label:       "End Date",
+			Description: "Date the prices apply until, open-ended when omitted",
+			DataType:    "datetime",
+			Required:    false,
+		},
+		{
+			Tag:         "unit",
+			Label:       "Price Unit",
+			Description: "Unit the prices are quoted per, which must measure the ration category",
+			DataType:    "unit",
+			Required:    true,
+		},
+		{
+			Tag:         "marketPrice",
+			Label:       "Market Price",
+			Description: "Market price per price unit, e.g. \"60.00\"",
+			DataType:    "money",
+			Required:    true,
+		},
+		{
+			Tag:         "subsidizedPrice",
+			Label:       "Subsidized Price",
+			Description: "Price per price unit paid by the member, e.g. \"30.00\"",
+			DataType:    "money",
+			Required:    true,
+		},
+		{
+			Tag:         "subsidyShare",
+			Label:       "Subsidy Share",
+			Description: "Percentage of the subsidy reimbursed by the government, 100 by default",
+			DataType:    "integer",
+			Required:    false,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		category, _ := req["category"].(datatypes.RationCategory)
+		cardCategory, _ := req["cardCategory"].(datatypes.RationCardCategory)
+		startDate, _ := req["startDate"].(time.Time)
+		endDate, hasEndDate := req["endDate"].(time.Time)
+		unit, _ := req["unit"].(datatypes.Unit)
+		marketPrice, _ := req["marketPrice"].(datatypes.Money)
+		subsidizedPrice, _ := req["subsidizedPrice"].(datatypes.Money)
+		subsidyShare := 100
+		if share, ok := req["subsidyShare"]; ok {
+			subsidyShare = toInt(share)
+		}
+
+		if hasEndDate && !endDate.After(startDate) {
+			return nil, errors.NewCCError("end date must be after the start date", http.StatusBadRequest)
+		}
+		if _, err := category.BaseAmount(datatypes.Quantity{Value: 1, Unit: unit}); err != nil {
+			return nil, err
+		}
+		if subsidizedPrice > marketPrice {
+			return nil, errors.NewCCError("subsidized price cannot exceed the market price", http.StatusBadRequest)
+		}
+
+		scheduleMap := map[string]interface{}{
+			"@assetType":      "priceSchedule",
+			"category":        category,
+			"cardCategory":    cardCategory,
+			"startDate":       startDate,
+			"unit":            unit,
+			"marketPrice":     marketPrice,
+			"subsidizedPrice": subsidizedPrice,
+			"subsidyShare":    subsidyShare,
+		}
+		if hasEndDate {
+			scheduleMap["endDate"] = endDate
+		}
+
+		scheduleAsset, err := assets.NewAsset(scheduleMap)
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to create a new asset")
+		}
+
+		// Prices of past sales must not change, so schedules are never overwritten
+		exists, err := scheduleAsset.ExistsInLedger(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to check price schedule existence")
+		}
+		if exists {
+			return nil, errors.NewCCError("a price schedule already starts on this date", http.StatusConflict)
+		}
+
+		scheduleAssetMap, err := scheduleAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Error saving asset on blockchain")
+		}
+
+		scheduleJSON, nerr := json.Marshal(scheduleAssetMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "priceScheduleSetLog", scheduleAsset.Key(), fmt.Sprintf("Price of %s for %s ration cards set to %s per %s", category.Label(), cardCategory.Label(), subsidizedPrice, unit))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		payload := eventtypes.PriceScheduleSetPayload{
+			EventPayload:    eventPayload,
+			Category:        category.Label(),
+			CardCategory:    cardCategory.Label(),
+			StartDate:       startDate.Format(time.RFC3339),
+			Unit:            unit,
+			MarketPrice:     marketPrice,
+			SubsidizedPrice: subsidizedPrice,
+			SubsidyShare:    subsidyShare,
+		}
+		if hasEndDate {
+			payload.EndDate = endDate.Format(time.RFC3339)
+		}
+		logMsg, nerr := json.Marshal(payload)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "priceScheduleSetLog", logMsg)
+
+		return scheduleJSON, nil
+	},
+})
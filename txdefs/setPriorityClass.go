@@ -0,0 +1,137 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// SetPriorityClass configures the priority service given to the members meeting a criterion.
+// Setting a class again replaces its configuration, and an inactive class gives no priority.
+// Classes raise entitlements, so setting them requires approval once the transaction has an
+// approval policy.
+var SetPriorityClass = requireApproval(tx.Transaction{
+	Tag:         "setPriorityClass",
+	Label:       "Set Priority Class",
+	Description: "Configure the priority service given to disabled, elderly or pregnant members",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{ // Only org1 admin can call this transaction
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "criterion",
+			Label:       "Criterion",
+			Description: "Criterion members must meet to belong to the class",
+			DataType:    "priorityCriterion",
+			Required:    true,
+		},
+		{
+			Tag:         "minimumAge",
+			Label:       "Minimum Age",
+			Description: "Age from which members are elderly, required for the elderly criterion",
+			DataType:    "integer",
+		},
+		{
+			Tag:         "reservedSlotShare",
+			Label:       "Reserved Slot Share",
+			Description: "Percentage of the daily pickup slots of every distribution point reserved to priority members",
+			DataType:    "integer",
+		},
+		{
+			Tag:         "entitlementBonus",
+			Label:       "Entitlement Bonus",
+			Description: "Percentage added to the ration quantity members of the class may collect",
+			DataType:    "integer",
+		},
+		{
+			Tag:         "homeDelivery",
+			Label:       "Home Delivery",
+			Description: "Members of the class may have their rations delivered at home",
+			DataType:    "boolean",
+		},
+		{
+			Tag:         "active",
+			Label:       "Active",
+			Description: "Whether the class gives priority, true when omitted",
+			DataType:    "boolean",
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		criterion, _ := req["criterion"].(datatypes.PriorityCriterion)
+		minimumAge := toInt(req["minimumAge"])
+		reservedSlotShare := toInt(req["reservedSlotShare"])
+		entitlementBonus := toInt(req["entitlementBonus"])
+		homeDelivery, _ := req["homeDelivery"].(bool)
+		active, ok := req["active"].(bool)
+		if !ok {
+			active = true
+		}
+		if criterion == datatypes.PriorityCriterionElderly && minimumAge <= 0 {
+			return nil, errors.NewCCError("minimumAge is required for the elderly criterion", http.StatusBadRequest)
+		}
+
+		classMap := map[string]interface{}{
+			"@assetType":        "priorityClass",
+			"criterion":         criterion,
+			"reservedSlotShare": reservedSlotShare,
+			"entitlementBonus":  entitlementBonus,
+			"homeDelivery":      homeDelivery,
+			"active":            active,
+		}
+		if criterion == datatypes.PriorityCriterionElderly {
+			classMap["minimumAge"] = minimumAge
+		}
+		classAsset, err := assets.NewAsset(classMap)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to build priority class", err.Status())
+		}
+		putClassMap, err := classAsset.Put(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to record priority class")
+		}
+
+		classJSON, nerr := json.Marshal(putClassMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "priorityClassSetLog", classAsset.Key(), fmt.Sprintf("Priority class %s set: %d%% of the slots reserved, %d%% entitlement bonus", criterion, reservedSlotShare, entitlementBonus))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.PriorityClassSetPayload{
+			EventPayload:      eventPayload,
+			Criterion:         criterion,
+			MinimumAge:        minimumAge,
+			ReservedSlotShare: reservedSlotShare,
+			EntitlementBonus:  entitlementBonus,
+			HomeDelivery:      homeDelivery,
+			Active:            active,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "priorityClassSetLog", logMsg)
+
+		return classJSON, nil
+	},
+})
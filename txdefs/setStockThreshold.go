@@ -0,0 +1,154 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var SetStockThreshold = requireApproval(tx.Transaction{
+	Tag:         "setStockThreshold",
+	Label:       "Set Stock Threshold",
+	Description: "Set the minimum stock level of a ration category at a distribution point",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{ // Only org1 admin can call this transaction
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "distributionPoint",
+			Label:       "Distribution Point",
+			Description: "Distribution Point",
+			DataType:    "->distributionPoint",
+			Required:    true,
+		},
+		{
+			Tag:         "category",
+			Label:       "Ration Category",
+			Description: "Ration Category",
+			DataType:    "rationCategory",
+			Required:    true,
+		},
+		{
+			Tag:         "minimumLevel",
+			Label:       "Minimum Stock Level",
+			Description: "Level under which a low stock alert is raised, in any unit of the category, 0 disables alerts",
+			DataType:    "quantity",
+			Required:    true,
+		},
+		{
+			Tag:         "targetLevel",
+			Label:       "Target Stock Level",
+			Description: "Level the stock should be replenished up to, in any unit of the category, defaults to twice the minimum level",
+			DataType:    "quantity",
+			Required:    false,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		distributionPointKey, ok := req["distributionPoint"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter distributionPoint must be an asset")
+		}
+		category, _ := req["category"].(datatypes.RationCategory)
+		minimumLevel, err := category.BaseAmount(req["minimumLevel"].(datatypes.Quantity))
+		if err != nil {
+			return nil, err
+		}
+		if minimumLevel < 0 {
+			return nil, errors.NewCCError("minimum level cannot be negative", http.StatusBadRequest)
+		}
+
+		distributionPointMap, err := distributionPointKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get distribution point from the ledger", err.Status())
+		}
+		distributionPointId, _ := distributionPointMap["distributionPointId"].(string)
+
+		// Only operators assigned to the distribution point can act on it
+		if err := checkOperatorAssignment(stub, distributionPointId); err != nil {
+			return nil, err
+		}
+
+		// Make sure the stock exists before setting its thresholds
+		_, err = adjustStock(stub, distributionPointKey, distributionPointId, category, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		thresholds := map[string]interface{}{
+			"minimumLevel": minimumLevel,
+		}
+		if targetQuantity, ok := req["targetLevel"].(datatypes.Quantity); ok {
+			targetLevel, err := category.BaseAmount(targetQuantity)
+			if err != nil {
+				return nil, err
+			}
+			thresholds["targetLevel"] = targetLevel
+		}
+		key, err := stockKey(distributionPointKey, category)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build stock key")
+		}
+		_, err = putStock(stub, key, thresholds)
+		if err != nil {
+			return nil, err
+		}
+
+		// The new threshold may already be crossed by the current level
+		change, err := adjustStock(stub, distributionPointKey, distributionPointId, category, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		stockJSON, nerr := json.Marshal(change.Stock)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		targetLevel := toInt(change.Stock["targetLevel"])
+		if targetLevel <= minimumLevel {
+			targetLevel = 2 * minimumLevel
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "stockThresholdSetLog", key.Key(), fmt.Sprintf("Stock threshold of %s set at distribution point %s: %d %s", category.Label(), distributionPointId, minimumLevel, category.BaseUnit()))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.StockThresholdSetPayload{
+			EventPayload:        eventPayload,
+			DistributionPointID: distributionPointId,
+			Category:            category,
+			MinimumLevel:        minimumLevel,
+			TargetLevel:         targetLevel,
+			Unit:                category.BaseUnit(),
+			LowStockAlert:       change.LowStockAlert,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		err = events.CallEvent(stub, "stockThresholdSetLog", logMsg)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to emit event")
+		}
+
+		return stockJSON, nil
+	},
+})
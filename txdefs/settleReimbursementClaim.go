@@ -0,0 +1,116 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// SettleReimbursementClaim records the payment of the approved amount of a claim, made
+// outside the ledger, with its reference in the payment system
+var SettleReimbursementClaim = tx.Transaction{
+	Tag:         "settleReimbursementClaim",
+	Label:       "Settle Reimbursement Claim",
+	Description: "Record the payment of an approved reimbursement claim",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{ // Only org2 admin can call this transaction
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "claim",
+			Label:       "Reimbursement Claim",
+			Description: "Reimbursement Claim",
+			DataType:    "->reimbursementClaim",
+			Required:    true,
+		},
+		{
+			Tag:         "paymentReference",
+			Label:       "Payment Reference",
+			Description: "Reference of the payment in the external payment system",
+			DataType:    "string",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		claimKey, ok := req["claim"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter claim must be an asset")
+		}
+		paymentReference, _ := req["paymentReference"].(string)
+		paymentReference = strings.TrimSpace(paymentReference)
+		if paymentReference == "" {
+			return nil, errors.NewCCError("payment reference is required", http.StatusBadRequest)
+		}
+
+		claimMap, err := getClaim(stub, claimKey, datatypes.ReimbursementClaimStatusApproved, datatypes.ReimbursementClaimStatusPartiallyApproved)
+		if err != nil {
+			return nil, err
+		}
+		month, _ := claimMap["month"].(string)
+		distributorId, err := claimDistributorId(stub, claimMap)
+		if err != nil {
+			return nil, err
+		}
+		amountPaid, err := datatypes.MoneyOf(claimMap["amountApproved"])
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "invalid amount approved", err.Status())
+		}
+
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+
+		updatedClaimMap, err := claimKey.Update(stub, map[string]interface{}{
+			"status":           datatypes.ReimbursementClaimStatusSettled,
+			"paymentReference": paymentReference,
+			"settlementDate":   txTimestamp.AsTime(),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to update reimbursement claim")
+		}
+
+		updatedClaimJSON, nerr := json.Marshal(updatedClaimMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "reimbursementClaimSettledLog", claimKey.Key(), fmt.Sprintf("Claim of distributor %s for %s settled: %s paid with %s", distributorId, month, amountPaid, paymentReference))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.ReimbursementClaimSettledPayload{
+			EventPayload:     eventPayload,
+			DistributorID:    distributorId,
+			Month:            month,
+			AmountPaid:       amountPaid,
+			PaymentReference: paymentReference,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "reimbursementClaimSettledLog", logMsg)
+
+		return updatedClaimJSON, nil
+	},
+}
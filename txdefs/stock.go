@@ -0,0 +1,172 @@
+package txdefs
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+)
+
+// stockChange is the outcome of a stock adjustment
+type stockChange struct {
+	Stock map[string]interface{}
+	Level int
+
+	// LowStockAlert is set when the adjustment made the stock cross its minimum level
+	LowStockAlert *eventtypes.LowStockAlertPayload
+}
+
+// stockKey returns the key of the stock of a ration category at a distribution point
+func stockKey(distributionPointKey assets.Key, category datatypes.RationCategory) (assets.Key, errors.ICCError) {
+	return assets.NewKey(map[string]interface{}{
+		"@assetType":        "stock",
+		"distributionPoint": distributionPointKey,
+		"category":          category,
+	})
+}
+
+// adjustStock adds delta, in the base unit of the category, to the stock of a ration category at a distribution point,
+// refusing to go below zero. The first time the stock goes under its minimum level, the alert
+// is recorded as a restockRequest and the returned change carries it for the transaction's
+// event. The alert is armed again once the stock gets back to the minimum level.
+func adjustStock(stub *sw.StubWrapper, distributionPointKey assets.Key, distributionPointId string, category datatypes.RationCategory, delta int) (*stockChange, errors.ICCError) {
+	key, err := stockKey(distributionPointKey, category)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to build stock key")
+	}
+
+	exists, err := key.ExistsInLedger(stub)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to check stock existence")
+	}
+
+	stockMap := map[string]interface{}{}
+	if exists {
+		stockMap, err = key.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get stock from the ledger", err.Status())
+		}
+	}
+
+	level := toInt(stockMap["quantity"]) + delta
+	if level < 0 {
+		return nil, errors.NewCCError(fmt.Sprintf("insufficient stock of %s at distribution point %s", category.Label(), distributionPointId), http.StatusBadRequest)
+	}
+
+	if !exists {
+		stockAsset, err := assets.NewAsset(map[string]interface{}{
+			"@assetType":        "stock",
+			"distributionPoint": distributionPointKey,
+			"category":          category,
+			"quantity":          level,
+			"unit":              category.BaseUnit(),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "Failed to create a new asset")
+		}
+
+		stockMap, err = stockAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "Error saving asset on blockchain")
+		}
+
+		return &stockChange{Stock: stockMap, Level: level}, nil
+	}
+
+	change := &stockChange{Level: level}
+	update := map[string]interface{}{
+		"quantity": level,
+		"unit":     category.BaseUnit(),
+	}
+
+	minimumLevel := toInt(stockMap["minimumLevel"])
+	belowMinimum, _ := stockMap["belowMinimum"].(bool)
+	if minimumLevel > 0 && level < minimumLevel && !belowMinimum {
+		update["belowMinimum"] = true
+
+		targetLevel := toInt(stockMap["targetLevel"])
+		if targetLevel <= minimumLevel {
+			targetLevel = 2 * minimumLevel
+		}
+
+		change.LowStockAlert, err = newLowStockAlert(stub, key.Key(), distributionPointId, category, level, targetLevel-level)
+		if err != nil {
+			return nil, err
+		}
+		err = recordRestockRequest(stub, change.LowStockAlert)
+		if err != nil {
+			return nil, err
+		}
+	} else if belowMinimum && level >= minimumLevel {
+		update["belowMinimum"] = false
+	}
+
+	change.Stock, err = putStock(stub, key, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// putStock writes the stock back to the ledger with the given changes applied.
+// Key.Update is not used as it rejects the integer props it has just parsed.
+func putStock(stub *sw.StubWrapper, key assets.Key, changes map[string]interface{}) (map[string]interface{}, errors.ICCError) {
+	stockMap, err := key.GetMap(stub)
+	if err != nil {
+		return nil, errors.WrapErrorWithStatus(err, "failed to get stock from the ledger", err.Status())
+	}
+	for prop, value := range changes {
+		stockMap[prop] = value
+	}
+
+	stockAsset, err := assets.NewAsset(stockMap)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to build stock asset")
+	}
+
+	stockMap, err = stockAsset.Put(stub)
+	if err != nil {
+		return nil, errors.WrapError(err, "failed to update stock")
+	}
+
+	return stockMap, nil
+}
+
+// recordRestockRequest records a low stock alert as an open restockRequest in the state of the
+// channel, for the ministry to order the replenishment. Fabric keeps a single event per
+// transaction, so the alerts of transactions logging their own event cannot reach
+// receiveLowStockAlert through the CCAPI.
+func recordRestockRequest(stub *sw.StubWrapper, alert *eventtypes.LowStockAlertPayload) errors.ICCError {
+	alertDate, nerr := time.Parse(time.RFC3339, alert.Timestamp)
+	if nerr != nil {
+		return errors.WrapError(nerr, "failed to parse alert date")
+	}
+
+	// A transaction may cross the minimum level of several stocks
+	restockRequestAsset, err := assets.NewAsset(map[string]interface{}{
+		"@assetType":          "restockRequest",
+		"requestId":           alert.TxID + ":" + alert.AssetKey,
+		"sourceChannel":       alert.SourceChannel,
+		"distributionPointId": alert.DistributionPointID,
+		"category":            alert.Category,
+		"currentLevel":        alert.CurrentLevel,
+		"suggestedQuantity":   alert.SuggestedQuantity,
+		"unit":                alert.Unit,
+		"alertDate":           alertDate,
+		"status":              "open",
+	})
+	if err != nil {
+		return errors.WrapError(err, "failed to build restock request")
+	}
+	_, err = restockRequestAsset.PutNew(stub)
+	if err != nil {
+		return errors.WrapError(err, "failed to record restock request")
+	}
+	return nil
+}
@@ -0,0 +1,414 @@
+package txdefs
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/cardtoken"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// MaxOfflineBatchSize is the maximum number of distributions replayed by a single submitOfflineBatch call
+const MaxOfflineBatchSize = 500
+
+// offlineResult is the outcome of the replay of a distribution of an offline batch
+type offlineResult struct {
+	Sequence int                              `json:"sequence"`
+	Sale     string                           `json:"sale,omitempty"`
+	Reason   datatypes.OfflineExceptionReason `json:"reason,omitempty"`
+	Detail   string                           `json:"detail,omitempty"`
+}
+
+// SubmitOfflineBatch replays the distributions a device recorded while its distribution point
+// was offline, in the order of their sequence and at the time the device recorded them. Each
+// one is checked like buyRation does, except for the pickup code which cannot be verified
+// offline. Distributions conflicting with the ledger are recorded as offlineException assets
+// for a supervisor to review instead of failing the batch: over the ration quantity, a second
+// pickup of the category by the member in the month, out of stock, or invalid.
+var SubmitOfflineBatch = tx.Transaction{
+	Tag:         "submitOfflineBatch",
+	Label:       "Submit Offline Batch",
+	Description: "Replay a signed batch of distributions recorded offline, recording conflicts as exceptions",
+	Method:      "POST",
+	Callers: []accesscontrol.Caller{ // Only org1 admin can call this transaction
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "device",
+			Label:       "Device",
+			Description: "Device which recorded the distributions",
+			DataType:    "->offlineDevice",
+			Required:    true,
+		},
+		{
+			Tag:         "distributions",
+			Label:       "Distributions",
+			Description: fmt.Sprintf("Distributions recorded offline in sequence order, at most %d", MaxOfflineBatchSize),
+			DataType:    "[]offlineDistribution",
+			Required:    true,
+		},
+		{
+			Tag:         "signature",
+			Label:       "Signature",
+			Description: "Base64 Ed25519 signature of the batch by the device",
+			DataType:    "string",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		deviceKey, ok := req["device"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter device must be an asset")
+		}
+		signature, _ := req["signature"].(string)
+		items, _ := req["distributions"].([]interface{})
+		if len(items) > MaxOfflineBatchSize {
+			return nil, errors.NewCCError(fmt.Sprintf("a batch holds at most %d distributions", MaxOfflineBatchSize), http.StatusBadRequest)
+		}
+		distributions := []datatypes.OfflineDistribution{}
+		for _, item := range items {
+			distribution, _ := item.(datatypes.OfflineDistribution)
+			distributions = append(distributions, distribution)
+		}
+
+		deviceMap, err := deviceKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get offline device from the ledger", err.Status())
+		}
+		deviceId, _ := deviceMap["deviceId"].(string)
+
+		distributionPointKey, err := assets.NewKey(map[string]interface{}{"@assetType": "distributionPoint", "@key": referenceKey(deviceMap["distributionPoint"])})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build distribution point key")
+		}
+		distributionPointMap, err := distributionPointKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get distribution point from the ledger", err.Status())
+		}
+		distributionPointId, _ := distributionPointMap["distributionPointId"].(string)
+		distributorRef, _ := distributionPointMap["distributor"].(map[string]interface{})
+
+		// Only operators assigned to the distribution point can act on it
+		if err := checkOperatorAssignment(stub, distributionPointId); err != nil {
+			return nil, err
+		}
+
+		// The device signs the batch, so it cannot be altered on its way to the ledger
+		publicKeyB64, _ := deviceMap["publicKey"].(string)
+		publicKey, nerr := cardtoken.DecodePublicKey(publicKeyB64)
+		if nerr != nil {
+			return nil, errors.WrapErrorWithStatus(nerr, "invalid device key on the ledger", http.StatusInternalServerError)
+		}
+		signatureBytes, nerr := base64.StdEncoding.DecodeString(signature)
+		if nerr != nil || !ed25519.Verify(publicKey, datatypes.OfflineBatchMessage(deviceId, distributions), signatureBytes) {
+			return nil, errors.NewCCError(fmt.Sprintf("batch is not signed by device %s", deviceId), http.StatusForbidden)
+		}
+
+		// Sequences must follow the ones already replayed, so a batch is replayed once
+		lastSequence := toInt(deviceMap["lastSequence"])
+		firstSequence := distributions[0].Sequence
+		if firstSequence <= lastSequence {
+			return nil, errors.NewCCError(fmt.Sprintf("device %s already replayed sequences up to %d", deviceId, lastSequence), http.StatusConflict)
+		}
+		for i := 1; i < len(distributions); i++ {
+			if distributions[i].Sequence <= distributions[i-1].Sequence {
+				return nil, errors.NewCCError("distributions must be in increasing sequence order", http.StatusBadRequest)
+			}
+		}
+		batchLastSequence := distributions[len(distributions)-1].Sequence
+		missingSequences := batchLastSequence - lastSequence - len(distributions)
+
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+		classes, err := activePriorityClasses(stub)
+		if err != nil {
+			return nil, err
+		}
+		replay := &offlineReplay{
+			deviceId:             deviceId,
+			distributionPointKey: distributionPointKey,
+			distributionPointId:  distributionPointId,
+			distributorRef:       distributorRef,
+			now:                  txTimestamp.AsTime(),
+			priorityClasses:      classes,
+			collected:            map[string]bool{},
+		}
+
+		results := []offlineResult{}
+		exceptions := []offlineResult{}
+		lowStockAlerts := []eventtypes.LowStockAlertPayload{}
+		for _, distribution := range distributions {
+			result, stock, err := replay.replay(stub, distribution)
+			if err != nil {
+				return nil, err
+			}
+			if stock != nil && stock.LowStockAlert != nil {
+				lowStockAlerts = append(lowStockAlerts, *stock.LowStockAlert)
+			}
+			if result.Reason != "" {
+				exceptions = append(exceptions, result)
+			}
+			results = append(results, result)
+		}
+
+		batchId := stub.Stub.GetTxID()
+		batchAsset, err := assets.NewAsset(map[string]interface{}{
+			"@assetType":        "offlineBatch",
+			"batchId":           batchId,
+			"device":            deviceKey,
+			"distributionPoint": distributionPointKey,
+			"firstSequence":     firstSequence,
+			"lastSequence":      batchLastSequence,
+			"recorded":          len(distributions) - len(exceptions),
+			"exceptions":        len(exceptions),
+			"missingSequences":  missingSequences,
+			"submittedDate":     txTimestamp.AsTime(),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build offline batch")
+		}
+		batchMap, err := batchAsset.PutNew(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to record offline batch")
+		}
+		batchKey, err := assets.NewKey(batchMap)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build offline batch key")
+		}
+
+		for i, exception := range exceptions {
+			exceptionAsset, err := assets.NewAsset(map[string]interface{}{
+				"@assetType":        "offlineException",
+				"exceptionId":       fmt.Sprintf("%s:%d", deviceId, exception.Sequence),
+				"batch":             batchKey,
+				"distributionPoint": distributionPointKey,
+				"distribution":      replay.distribution(distributions, exception.Sequence),
+				"reason":            exception.Reason,
+				"detail":            exception.Detail,
+				"resolved":          false,
+			})
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to build offline exception")
+			}
+			_, err = exceptionAsset.PutNew(stub)
+			if err != nil {
+				return nil, errors.WrapErrorWithStatus(err, fmt.Sprintf("failed to record offline exception %d", i+1), err.Status())
+			}
+		}
+
+		_, err = deviceKey.Update(stub, map[string]interface{}{
+			"lastSequence": batchLastSequence,
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to update offline device")
+		}
+
+		responseJSON, nerr := json.Marshal(map[string]interface{}{
+			"batch":   batchMap,
+			"results": results,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "offlineBatchReplayedLog", batchAsset.Key(), fmt.Sprintf("Offline batch of device %s replayed: %d recorded, %d exception(s)", deviceId, len(distributions)-len(exceptions), len(exceptions)))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.OfflineBatchReplayedPayload{
+			EventPayload:        eventPayload,
+			BatchID:             batchId,
+			DeviceID:            deviceId,
+			DistributionPointID: distributionPointId,
+			FirstSequence:       firstSequence,
+			LastSequence:        batchLastSequence,
+			Recorded:            len(distributions) - len(exceptions),
+			Exceptions:          len(exceptions),
+			MissingSequences:    missingSequences,
+			LowStockAlerts:      lowStockAlerts,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "offlineBatchReplayedLog", logMsg)
+
+		return responseJSON, nil
+	},
+}
+
+// offlineReplay replays the distributions of an offline batch of a device
+type offlineReplay struct {
+	deviceId             string
+	distributionPointKey assets.Key
+	distributionPointId  string
+	distributorRef       map[string]interface{}
+	now                  time.Time
+	priorityClasses      []map[string]interface{}
+
+	// collected holds the member, category and month of the distributions replayed so far, as
+	// the sales recorded by the batch are not visible to searches until it is committed
+	collected map[string]bool
+}
+
+// distribution returns the distribution of a batch with the given sequence
+func (r *offlineReplay) distribution(distributions []datatypes.OfflineDistribution, sequence int) datatypes.OfflineDistribution {
+	for _, distribution := range distributions {
+		if distribution.Sequence == sequence {
+			return distribution
+		}
+	}
+	return datatypes.OfflineDistribution{}
+}
+
+// replay records the sale of a distribution. A distribution conflicting with the ledger is
+// not recorded and its result holds the reason; only failures of the ledger itself are errors.
+func (r *offlineReplay) replay(stub *sw.StubWrapper, distribution datatypes.OfflineDistribution) (offlineResult, *stockChange, errors.ICCError) {
+	result := offlineResult{Sequence: distribution.Sequence}
+	conflict := func(reason datatypes.OfflineExceptionReason, detail string) (offlineResult, *stockChange, errors.ICCError) {
+		result.Reason, result.Detail = reason, detail
+		return result, nil, nil
+	}
+	// Errors of the checks shared with buyRation are conflicts unless the ledger failed
+	invalid := func(err errors.ICCError) (offlineResult, *stockChange, errors.ICCError) {
+		if err.Status() >= http.StatusInternalServerError {
+			return result, nil, err
+		}
+		return conflict(datatypes.OfflineExceptionReasonInvalid, err.Message())
+	}
+
+	if distribution.RecordedAt.After(r.now) {
+		return conflict(datatypes.OfflineExceptionReasonInvalid, "recorded after its submission, the device clock is wrong")
+	}
+
+	// Find the member holding the ration card
+	query := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"@assetType":       "member",
+			"rationCardNumber": distribution.RationCardNumber,
+		},
+	}
+	excludeArchived(query)
+	response, err := assets.Search(stub, query, "", false)
+	if err != nil {
+		return result, nil, errors.WrapErrorWithStatus(err, "error searching for ration card holder", 500)
+	}
+	if len(response.Result) == 0 {
+		return conflict(datatypes.OfflineExceptionReasonInvalid, "no member holds this ration card")
+	}
+	memberMap := response.Result[0]
+	if memberMap["rationCardStatus"] != string(datatypes.RationCardStatusActive) {
+		return conflict(datatypes.OfflineExceptionReasonInvalid, "ration card is not active")
+	}
+	cardCategory, ok := memberMap["rationCardCategory"].(float64)
+	if !ok {
+		return conflict(datatypes.OfflineExceptionReasonInvalid, "ration card has no category to price the ration")
+	}
+	collectedBy, nomineeKey, err := collectorOf(stub, memberMap, distribution.CollectorNID, distribution.RecordedAt)
+	if err != nil {
+		return invalid(err)
+	}
+
+	rationKey, err := assets.NewKey(map[string]interface{}{"@assetType": "ration", "@key": distribution.Ration})
+	if err != nil {
+		return invalid(err)
+	}
+	rationMap, err := rationKey.GetMap(stub)
+	if err != nil {
+		return conflict(datatypes.OfflineExceptionReasonInvalid, "ration is not on the ledger")
+	}
+	if archived, _ := rationMap["archived"].(bool); archived {
+		return conflict(datatypes.OfflineExceptionReasonInvalid, "ration is archived")
+	}
+	category, rationQuantity, err := rationAmount(rationMap)
+	if err != nil {
+		return invalid(err)
+	}
+	amount, err := category.BaseAmount(distribution.Quantity)
+	if err != nil {
+		return invalid(err)
+	}
+	if amount <= 0 {
+		return conflict(datatypes.OfflineExceptionReasonInvalid, "quantity must be greater than 0")
+	}
+	if entitlement := memberPriority(memberMap, r.priorityClasses, distribution.RecordedAt).entitlement(rationQuantity); amount > entitlement {
+		return conflict(datatypes.OfflineExceptionReasonOverQuota, fmt.Sprintf("%s handed out, the member is entitled to %d %s", distribution.Quantity, entitlement, category.BaseUnit()))
+	}
+	rationId, _ := rationMap["id"].(string)
+
+	distributionSale := &sale{
+		ID:                  fmt.Sprintf("%s:%d", r.deviceId, distribution.Sequence),
+		Member:              memberMap,
+		Ration:              rationKey,
+		RationID:            rationId,
+		DistributionPoint:   r.distributionPointKey,
+		DistributionPointID: r.distributionPointId,
+		Distributor:         r.distributorRef,
+		Category:            category,
+		Amount:              amount,
+		Date:                distribution.RecordedAt,
+		CollectedBy:         collectedBy,
+		Nominee:             nomineeKey,
+	}
+	if nomineeKey != nil {
+		distributionSale.NomineeNID = distribution.CollectorNID
+	}
+
+	// A member picks a ration category up once a month
+	memberKey, _ := memberMap["@key"].(string)
+	month := distribution.RecordedAt.Format(saleMonthLayout)
+	pickup := fmt.Sprintf("%s|%v|%s", memberKey, category, month)
+	if r.collected[pickup] {
+		return conflict(datatypes.OfflineExceptionReasonDoublePickup, fmt.Sprintf("member already collected %s in %s earlier in the batch", category.Label(), month))
+	}
+	err = distributionSale.checkMonthlyPickup(stub)
+	if err != nil {
+		if err.Status() == http.StatusConflict {
+			return conflict(datatypes.OfflineExceptionReasonDoublePickup, err.Message())
+		}
+		return result, nil, err
+	}
+	err = distributionSale.price(stub, datatypes.RationCardCategory(cardCategory))
+	if err != nil {
+		return invalid(err)
+	}
+
+	stock, err := adjustStock(stub, r.distributionPointKey, r.distributionPointId, category, -amount)
+	if err != nil {
+		if err.Status() == http.StatusBadRequest {
+			return conflict(datatypes.OfflineExceptionReasonOutOfStock, err.Message())
+		}
+		return result, nil, err
+	}
+
+	saleKey, _, err := distributionSale.record(stub)
+	if err != nil {
+		return result, nil, err
+	}
+	r.collected[pickup] = true
+	result.Sale = saleKey.Key()
+
+	return result, stock, nil
+}
@@ -0,0 +1,101 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+var UnassignOperator = tx.Transaction{
+	Tag:         "unassignOperator",
+	Label:       "Unassign Operator",
+	Description: "Revoke the access of an operator to a distribution point",
+	Method:      "DELETE",
+	Callers: []accesscontrol.Caller{ // Only orgMSP admin can call this transaction
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "operatorMsp",
+			Label:       "Operator MSP",
+			Description: "MSP ID of the organization which issued the operator certificate",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "operatorId",
+			Label:       "Operator ID",
+			Description: "Operator ID, as found in the operatorId attribute of the operator certificate",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "distributionPoint",
+			Label:       "Distribution Point",
+			Description: "Distribution point the operator is removed from",
+			DataType:    "->distributionPoint",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		operatorMsp, _ := req["operatorMsp"].(string)
+		operatorId, _ := req["operatorId"].(string)
+		distributionPointKey, ok := req["distributionPoint"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter distributionPoint must be an asset")
+		}
+
+		distributionPointMap, err := distributionPointKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get distribution point from the ledger", err.Status())
+		}
+		distributionPointId, _ := distributionPointMap["distributionPointId"].(string)
+
+		key, err := operatorAssignmentKey(operatorMsp, operatorId, distributionPointId)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build operator assignment key")
+		}
+		exists, err := key.ExistsInLedger(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to check operator assignment")
+		}
+		if !exists {
+			return nil, errors.NewCCError(fmt.Sprintf("operator %s of %s is not assigned to distribution point %s", operatorId, operatorMsp, distributionPointId), http.StatusNotFound)
+		}
+
+		response, err := key.Delete(stub)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to delete operator assignment")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "operatorUnassignedLog", key.Key(), fmt.Sprintf("Operator %s unassigned from distribution point %s", operatorId, distributionPointId))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.OperatorUnassignedPayload{
+			EventPayload:        eventPayload,
+			OperatorMSP:         operatorMsp,
+			OperatorID:          operatorId,
+			DistributionPointID: distributionPointId,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
+		}
+
+		events.CallEvent(stub, "operatorUnassignedLog", logMsg)
+
+		return response, nil
+	},
+}
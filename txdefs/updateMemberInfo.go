@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
 	"github.com/hyperledger-labs/cc-tools/accesscontrol"
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
@@ -91,6 +93,13 @@ var UpdateMemberInfo = tx.Transaction{
 			DataType:    "boolean",
 			Required:    false,
 		},
+		{
+			Tag:         "pregnant",
+			Label:       "Pregnant",
+			Description: "Pregnant",
+			DataType:    "boolean",
+			Required:    false,
+		},
 		{
 			Tag:         "rationCardNumber",
 			Label:       "Ration Card Number",
@@ -142,44 +151,67 @@ var UpdateMemberInfo = tx.Transaction{
 
 		// Update the member asset with the provided information
 		memberMap := (map[string]interface{})(*memberAsset)
+		statusBefore, _ := memberMap["rationCardStatus"].(string)
+		updatedFields := []string{}
 		if name, ok := req["name"].(string); ok {
 			memberMap["name"] = name
+			updatedFields = append(updatedFields, "name")
 		}
 		if dateOfBirth, ok := req["dateOfBirth"].(string); ok {
 			memberMap["dateOfBirth"] = dateOfBirth
+			updatedFields = append(updatedFields, "dateOfBirth")
 		}
 		if height, ok := req["height"].(float64); ok {
 			memberMap["height"] = height
+			updatedFields = append(updatedFields, "height")
 		}
-		if address, ok := req["address"].(string); ok {
+		if address, ok := req["address"].(datatypes.Address); ok {
+			err := checkAddress(stub, address)
+			if err != nil {
+				return nil, err
+			}
 			memberMap["address"] = address
+			updatedFields = append(updatedFields, "address")
 		}
 		if contactInformation, ok := req["contactInformation"].(string); ok {
 			memberMap["contactInformation"] = contactInformation
+			updatedFields = append(updatedFields, "contactInformation")
 		}
 		if familySize, ok := req["familySize"].(int); ok {
 			memberMap["familySize"] = familySize
+			updatedFields = append(updatedFields, "familySize")
 		}
 		if income, ok := req["income"].(int); ok {
 			memberMap["income"] = income
+			updatedFields = append(updatedFields, "income")
 		}
 		if disabilityStatus, ok := req["disabilityStatus"].(bool); ok {
 			memberMap["disabilityStatus"] = disabilityStatus
+			updatedFields = append(updatedFields, "disabilityStatus")
+		}
+		if pregnant, ok := req["pregnant"].(bool); ok {
+			memberMap["pregnant"] = pregnant
+			updatedFields = append(updatedFields, "pregnant")
 		}
 		if rationCardNumber, ok := req["rationCardNumber"].(string); ok {
 			memberMap["rationCardNumber"] = rationCardNumber
+			updatedFields = append(updatedFields, "rationCardNumber")
 		}
 		if rationCardStatus, ok := req["rationCardStatus"].(string); ok {
 			memberMap["rationCardStatus"] = rationCardStatus
+			updatedFields = append(updatedFields, "rationCardStatus")
 		}
 		if rationCardIssuedDate, ok := req["rationCardIssuedDate"].(string); ok {
 			memberMap["rationCardIssuedDate"] = rationCardIssuedDate
+			updatedFields = append(updatedFields, "rationCardIssuedDate")
 		}
 		if rationCardExpiryDate, ok := req["rationCardExpiryDate"].(string); ok {
 			memberMap["rationCardExpiryDate"] = rationCardExpiryDate
+			updatedFields = append(updatedFields, "rationCardExpiryDate")
 		}
 		if rationCardCategory, ok := req["rationCardCategory"].(string); ok {
 			memberMap["rationCardCategory"] = rationCardCategory
+			updatedFields = append(updatedFields, "rationCardCategory")
 		}
 
 		updatedMemberAsset, err := memberAsset.Update(stub, memberMap)
@@ -194,7 +226,18 @@ var UpdateMemberInfo = tx.Transaction{
 		}
 
 		// Marshal message to be logged
-		logMsg, erre := json.Marshal(fmt.Sprintf("Member information updated for NID: %s", nid))
+		statusAfter, _ := memberMap["rationCardStatus"].(string)
+		eventPayload, err := eventtypes.NewEventPayload(stub, "memberInfoUpdatedLog", memberAsset.Key(), fmt.Sprintf("Member information updated for NID: %s", nid))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, erre := json.Marshal(eventtypes.MemberInfoUpdatedPayload{
+			EventPayload:  eventPayload,
+			NID:           nid,
+			UpdatedFields: updatedFields,
+			StatusBefore:  statusBefore,
+			StatusAfter:   statusAfter,
+		})
 		if erre != nil {
 			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
 		}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
 	"github.com/hyperledger-labs/cc-tools/accesscontrol"
 	"github.com/hyperledger-labs/cc-tools/assets"
 	"github.com/hyperledger-labs/cc-tools/errors"
@@ -67,8 +69,8 @@ var UpdateRation = tx.Transaction{
 		{
 			Tag:         "quantity",
 			Label:       "Ration Quantity",
-			Description: "Ration Quantity",
-			DataType:    "integer",
+			Description: "Ration Quantity, in a unit of its category",
+			DataType:    "quantity",
 			Required:    true,
 		},
 		{
@@ -109,6 +111,10 @@ var UpdateRation = tx.Transaction{
 
 		// Update the ration asset with the provided information
 		rationMap := (map[string]interface{})(*rationAsset)
+		category, quantityBefore, err := rationAmount(rationMap)
+		if err != nil {
+			return nil, err
+		}
 		if category, ok := req["category"].(string); ok {
 			rationMap["category"] = category
 		}
@@ -121,7 +127,10 @@ var UpdateRation = tx.Transaction{
 		if distributedBy, ok := req["distributedBy"].(string); ok {
 			rationMap["distributedBy"] = distributedBy
 		}
-		if quantity, ok := req["quantity"].(int); ok {
+		if quantity, ok := req["quantity"].(datatypes.Quantity); ok {
+			if _, err := category.BaseAmount(quantity); err != nil {
+				return nil, err
+			}
 			rationMap["quantity"] = quantity
 		}
 		if expiryDate, ok := req["expiryDate"].(time.Time); ok {
@@ -146,7 +155,21 @@ var UpdateRation = tx.Transaction{
 		}
 
 		// Marshal message to be logged
-		logMsg, erre := json.Marshal(fmt.Sprintf("Ration updated: %s", id))
+		_, quantityAfter, err := rationAmount(updatedRationAsset)
+		if err != nil {
+			return nil, err
+		}
+		eventPayload, err := eventtypes.NewEventPayload(stub, "rationUpdatedLog", rationAsset.Key(), fmt.Sprintf("Ration updated: %s", id))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, erre := json.Marshal(eventtypes.RationUpdatedPayload{
+			EventPayload:   eventPayload,
+			RationID:       id,
+			QuantityBefore: quantityBefore,
+			QuantityAfter:  quantityAfter,
+			Unit:           category.BaseUnit(),
+		})
 		if erre != nil {
 			return nil, errors.WrapError(nil, "failed to encode asset to JSON format")
 		}
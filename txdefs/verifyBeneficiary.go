@@ -0,0 +1,175 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// VerifyBeneficiary checks the fingerprint presented at a distribution point against the one
+// bound to the holder of a ration card. As with verifyPickupCode, a mismatch is answered with
+// match set to false rather than an error, for the attempt to be recorded; the binding is locked
+// after MaxBiometricAttempts consecutive mismatches, until the member binds again.
+var VerifyBeneficiary = tx.Transaction{
+	Tag:         "verifyBeneficiary",
+	Label:       "Verify Beneficiary",
+	Description: "Check the fingerprint of the holder of a ration card",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{ // Only org1 admin can call this transaction
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "rationCardNumber",
+			Label:       "Ration Card Number",
+			Description: "Ration Card Number",
+			DataType:    "string",
+			Required:    true,
+		},
+		{
+			Tag:         "distributionPoint",
+			Label:       "Distribution Point",
+			Description: "Distribution point checking the beneficiary",
+			DataType:    "->distributionPoint",
+			Required:    true,
+		},
+		{
+			Tag:         "templateHash",
+			Label:       "Template Hash",
+			Description: "Hex SHA-256 of the fingerprint template ID, computed by the reader",
+			DataType:    "string",
+			Required:    true,
+			Private:     true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		rationCardNumber, _ := req["rationCardNumber"].(string)
+		templateHash, _ := req["templateHash"].(string)
+		distributionPointKey, ok := req["distributionPoint"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter distributionPoint must be an asset")
+		}
+
+		distributionPointMap, err := distributionPointKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get distribution point from the ledger", err.Status())
+		}
+		distributionPointId, _ := distributionPointMap["distributionPointId"].(string)
+
+		// Only operators assigned to the distribution point can act on it
+		if err := checkOperatorAssignment(stub, distributionPointId); err != nil {
+			return nil, err
+		}
+
+		// Find the member holding the ration card
+		query := map[string]interface{}{
+			"selector": map[string]interface{}{
+				"@assetType":       "member",
+				"rationCardNumber": rationCardNumber,
+			},
+		}
+		excludeArchived(query)
+		response, err := assets.Search(stub, query, "", false)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "error searching for ration card holder", 500)
+		}
+		if len(response.Result) == 0 {
+			return nil, errors.NewCCError("no member holds this ration card", http.StatusNotFound)
+		}
+		memberMap := response.Result[0]
+		if memberMap["rationCardStatus"] != string(datatypes.RationCardStatusActive) {
+			return nil, errors.NewCCError("ration card is not active", http.StatusForbidden)
+		}
+		if bound, _ := memberMap["biometricBound"].(bool); !bound {
+			return nil, errors.NewCCError("no fingerprint is bound to the ration card holder", http.StatusNotFound)
+		}
+		memberKey, err := assets.NewKey(memberMap)
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to get member key")
+		}
+
+		bindingKey, err := assets.NewKey(map[string]interface{}{
+			"@assetType": "biometricBinding",
+			"member":     memberKey,
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build biometric binding key")
+		}
+		bindingMap, err := bindingKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get biometric binding from the ledger", err.Status())
+		}
+		if locked, _ := bindingMap["locked"].(bool); locked {
+			return nil, errors.NewCCError("fingerprint verification is locked, the member must bind their fingerprint again", http.StatusLocked)
+		}
+		salt, _ := bindingMap["salt"].(string)
+		previousAttempts := toInt(bindingMap["failedAttempts"])
+
+		match := checkTemplateHash(templateHash) == nil && bindingMap["templateHash"] == saltedTemplateHash(salt, templateHash)
+		// Only consecutive mismatches count towards the lockout
+		failedAttempts := 0
+		if !match {
+			failedAttempts = previousAttempts + 1
+		}
+		locked := failedAttempts >= MaxBiometricAttempts
+		if failedAttempts != previousAttempts {
+			_, err = bindingKey.Update(stub, map[string]interface{}{
+				"failedAttempts": failedAttempts,
+				"locked":         locked,
+			})
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to update biometric binding")
+			}
+		}
+
+		attemptsLeft := MaxBiometricAttempts - failedAttempts
+		if attemptsLeft < 0 {
+			attemptsLeft = 0
+		}
+		responseJSON, nerr := json.Marshal(map[string]interface{}{
+			"match":        match,
+			"attemptsLeft": attemptsLeft,
+			"locked":       locked,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "beneficiaryVerifiedLog", memberKey.Key(), fmt.Sprintf("Fingerprint of the holder of card %s checked at distribution point %s: match %t", rationCardNumber, distributionPointId, match))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.BeneficiaryVerifiedPayload{
+			EventPayload:        eventPayload,
+			RationCardNumber:    rationCardNumber,
+			DistributionPointID: distributionPointId,
+			Match:               match,
+			AttemptsLeft:        attemptsLeft,
+			Locked:              locked,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "beneficiaryVerifiedLog", logMsg)
+
+		return responseJSON, nil
+	},
+}
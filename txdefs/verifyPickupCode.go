@@ -0,0 +1,162 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// VerifyPickupCode checks the one-time code a member presents at the distribution point.
+// A failed transaction writes nothing, so wrong codes are answered with match set to false
+// rather than an error, for the attempt to be counted; the booking is locked after
+// MaxPickupCodeAttempts wrong codes. A matching code marks the booking verified, which
+// buyRation requires, with the code, to hand out the rations of the visit. The transaction must be endorsed
+// by the org3 peers, the only ones holding the pickupCode collection.
+var VerifyPickupCode = tx.Transaction{
+	Tag:         "verifyPickupCode",
+	Label:       "Verify Pickup Code",
+	Description: "Check the one-time code presented by a member for a pickup booking",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{ // Only org1 admin can call this transaction
+		{
+			MSP: "org1MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "pickupBooking",
+			Label:       "Pickup Booking",
+			Description: "Pickup Booking",
+			DataType:    "->pickupBooking",
+			Required:    true,
+		},
+		{
+			Tag:         "pickupCode",
+			Label:       "Pickup Code",
+			Description: "One-time code presented by the member",
+			DataType:    "string",
+			Required:    true,
+			Private:     true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		bookingKey, ok := req["pickupBooking"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter pickupBooking must be an asset")
+		}
+		pickupCode, _ := req["pickupCode"].(string)
+
+		bookingMap, err := bookingKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get pickup booking from the ledger", err.Status())
+		}
+		bookingId, _ := bookingMap["bookingId"].(string)
+		if bookingMap["status"] != string(datatypes.PickupBookingStatusBooked) {
+			return nil, errors.NewCCError(fmt.Sprintf("pickup booking is %v", bookingMap["status"]), http.StatusConflict)
+		}
+
+		distributionPointKey, err := assets.NewKey(map[string]interface{}{"@assetType": "distributionPoint", "@key": referenceKey(bookingMap["distributionPoint"])})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build distribution point key")
+		}
+		distributionPointMap, err := distributionPointKey.GetMap(stub)
+		if err != nil {
+			return nil, errors.WrapErrorWithStatus(err, "failed to get distribution point from the ledger", err.Status())
+		}
+		distributionPointId, _ := distributionPointMap["distributionPointId"].(string)
+
+		// Only operators assigned to the distribution point can act on it
+		if err := checkOperatorAssignment(stub, distributionPointId); err != nil {
+			return nil, err
+		}
+
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+		if !scheduleDate(bookingMap["expiryDate"]).After(txTimestamp.AsTime()) {
+			return nil, errors.NewCCError("pickup code has expired", http.StatusGone)
+		}
+
+		codeKey, codeMap, err := getPickupCode(stub, bookingKey)
+		if err != nil {
+			return nil, err
+		}
+		failedAttempts := toInt(codeMap["failedAttempts"])
+
+		match := checkPickupCode(pickupCode) == nil && codeMap["codeHash"] == pickupCodeHash(bookingId, pickupCode)
+		status := datatypes.PickupBookingStatusVerified
+		if !match {
+			failedAttempts++
+			_, err = codeKey.Update(stub, map[string]interface{}{
+				"failedAttempts": failedAttempts,
+			})
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to update pickup code")
+			}
+
+			status = datatypes.PickupBookingStatusBooked
+			if failedAttempts >= MaxPickupCodeAttempts {
+				status = datatypes.PickupBookingStatusLocked
+			}
+		}
+
+		updatedBookingMap := bookingMap
+		if status != datatypes.PickupBookingStatusBooked {
+			updatedBookingMap, err = bookingKey.Update(stub, map[string]interface{}{
+				"status": status,
+			})
+			if err != nil {
+				return nil, errors.WrapError(err, "failed to update pickup booking")
+			}
+		}
+
+		attemptsLeft := MaxPickupCodeAttempts - failedAttempts
+		if attemptsLeft < 0 {
+			attemptsLeft = 0
+		}
+		responseJSON, nerr := json.Marshal(map[string]interface{}{
+			"match":         match,
+			"attemptsLeft":  attemptsLeft,
+			"pickupBooking": updatedBookingMap,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "pickupCodeCheckedLog", bookingKey.Key(), fmt.Sprintf("Pickup code of booking %s checked at distribution point %s: %s", bookingId, distributionPointId, status))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.PickupCodeCheckedPayload{
+			EventPayload:        eventPayload,
+			BookingID:           bookingId,
+			DistributionPointID: distributionPointId,
+			Match:               match,
+			AttemptsLeft:        attemptsLeft,
+			Status:              status,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "pickupCodeCheckedLog", logMsg)
+
+		return responseJSON, nil
+	},
+}
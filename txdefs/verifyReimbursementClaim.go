@@ -0,0 +1,113 @@
+package txdefs
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/datatypes"
+	"github.com/hyperledger-labs/cc-tools-demo/chaincode/eventtypes"
+	"github.com/hyperledger-labs/cc-tools/accesscontrol"
+	"github.com/hyperledger-labs/cc-tools/assets"
+	"github.com/hyperledger-labs/cc-tools/errors"
+	"github.com/hyperledger-labs/cc-tools/events"
+	sw "github.com/hyperledger-labs/cc-tools/stubwrapper"
+	tx "github.com/hyperledger-labs/cc-tools/transactions"
+)
+
+// VerifyReimbursementClaim checks a claim against the ledger: each sale must exist, belong
+// to the distributor, the month and the claim, and claim the subsidy its price schedule
+// gives. Discrepancies are recorded on the claim as findings for the decision.
+var VerifyReimbursementClaim = tx.Transaction{
+	Tag:         "verifyReimbursementClaim",
+	Label:       "Verify Reimbursement Claim",
+	Description: "Check a reimbursement claim against the sales and price schedules on the ledger",
+	Method:      "PUT",
+	Callers: []accesscontrol.Caller{ // Only org2 admin can call this transaction
+		{
+			MSP: "org2MSP",
+			OU:  "admin",
+		},
+		{
+			MSP: "orgMSP",
+			OU:  "admin",
+		},
+	},
+	Args: []tx.Argument{
+		{
+			Tag:         "claim",
+			Label:       "Reimbursement Claim",
+			Description: "Reimbursement Claim",
+			DataType:    "->reimbursementClaim",
+			Required:    true,
+		},
+	},
+	Routine: func(stub *sw.StubWrapper, req map[string]interface{}) ([]byte, errors.ICCError) {
+		claimKey, ok := req["claim"].(assets.Key)
+		if !ok {
+			return nil, errors.WrapError(nil, "Parameter claim must be an asset")
+		}
+
+		// Claims may be verified again until they are decided
+		claimMap, err := getClaim(stub, claimKey, datatypes.ReimbursementClaimStatusRaised, datatypes.ReimbursementClaimStatusVerified)
+		if err != nil {
+			return nil, err
+		}
+		month, _ := claimMap["month"].(string)
+		distributorId, err := claimDistributorId(stub, claimMap)
+		if err != nil {
+			return nil, err
+		}
+
+		_, findings, err := checkClaimSales(stub, claimMap)
+		if err != nil {
+			return nil, err
+		}
+
+		verifiedBy, err := stub.GetMSPID()
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to get caller MSP")
+		}
+		txTimestamp, nerr := stub.Stub.GetTxTimestamp()
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to get transaction timestamp")
+		}
+
+		findingList := []interface{}{}
+		for _, finding := range findings {
+			findingList = append(findingList, finding)
+		}
+		updatedClaimMap, err := claimKey.Update(stub, map[string]interface{}{
+			"status":       datatypes.ReimbursementClaimStatusVerified,
+			"findings":     findingList,
+			"verifiedBy":   verifiedBy,
+			"verifiedDate": txTimestamp.AsTime(),
+		})
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to update reimbursement claim")
+		}
+
+		updatedClaimJSON, nerr := json.Marshal(updatedClaimMap)
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to marshal response")
+		}
+
+		// Marshal message to be logged
+		eventPayload, err := eventtypes.NewEventPayload(stub, "reimbursementClaimVerifiedLog", claimKey.Key(), fmt.Sprintf("Claim of distributor %s for %s verified with %d finding(s)", distributorId, month, len(findings)))
+		if err != nil {
+			return nil, errors.WrapError(err, "failed to build event payload")
+		}
+		logMsg, nerr := json.Marshal(eventtypes.ReimbursementClaimVerifiedPayload{
+			EventPayload:  eventPayload,
+			DistributorID: distributorId,
+			Month:         month,
+			Findings:      findings,
+		})
+		if nerr != nil {
+			return nil, errors.WrapError(nerr, "failed to encode event payload to JSON format")
+		}
+
+		events.CallEvent(stub, "reimbursementClaimVerifiedLog", logMsg)
+
+		return updatedClaimJSON, nil
+	},
+}